@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"stew/constants"
+	"stew/database"
+	"stew/types"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const apiKeyUsage = `Usage: %s apikey <command>
+
+Commands:
+  create NAME SCOPE... Create a key and print it once, e.g. gateway:read gateway:write network:*
+  list                 List every key and its scopes
+  revoke NAME          Revoke a key
+`
+
+func apiKeyUsageExit() {
+	fmt.Fprintf(os.Stderr, apiKeyUsage, os.Args[0])
+	os.Exit(2)
+}
+
+func APIKey(args []string, conf types.DatabaseConfig) {
+	if len(args) < 1 {
+		apiKeyUsageExit()
+	}
+	switch args[0] {
+	case "create":
+		if len(args) < 3 {
+			apiKeyUsageExit()
+		}
+		for _, scope := range args[2:] {
+			if !constants.IsKnownScope(scope) {
+				fmt.Fprintf(os.Stderr, "Unknown scope %s\n", scope)
+				os.Exit(2)
+			}
+		}
+	case "list":
+		if len(args) != 1 {
+			apiKeyUsageExit()
+		}
+	case "revoke":
+		if len(args) != 2 {
+			apiKeyUsageExit()
+		}
+	default:
+		apiKeyUsageExit()
+	}
+
+	db := database.LoadDatabase(conf)
+	defer db.Close()
+	database.ConnectDatabase(db)
+
+	switch args[0] {
+	case "create":
+		key, err := database.CreateAPIKey(args[1], args[2:])
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(key)
+	case "list":
+		keys, err := database.ListAPIKeys()
+		if err != nil {
+			panic(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSCOPES\tCREATED AT\tREVOKED")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%t\n", k.Id, k.Name, strings.Join(k.Scopes, " "), k.CreatedTime.Format(time.RFC1123Z), k.Revoked)
+		}
+		w.Flush()
+	case "revoke":
+		revoked, err := database.RevokeAPIKey(args[1])
+		if err != nil {
+			panic(err)
+		}
+		if !revoked {
+			fmt.Fprintf(os.Stderr, "No active key named %s\n", args[1])
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"stew/database"
+	"stew/types"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `Usage: %s migrate <command>
+
+Commands:
+  up                 Apply every pending migration
+  down [N]           Revert the latest N applied migrations (default 1)
+  status             List known and applied migrations
+  baseline [VERSION] Mark migrations up to VERSION (default latest) as applied without running them
+`
+
+func migrateUsageExit() {
+	fmt.Fprintf(os.Stderr, migrateUsage, os.Args[0])
+	os.Exit(2)
+}
+
+func printMigrationStatuses(statuses []types.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		name := status.Name
+		state := "pending"
+		appliedTime := "-"
+		if !status.Known {
+			name = "?"
+			state = "unknown"
+		}
+		if status.Applied {
+			if status.Known {
+				state = "applied"
+			}
+			appliedTime = status.AppliedTime.Format(time.RFC1123Z)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, name, state, appliedTime)
+	}
+	w.Flush()
+}
+
+func Migrate(args []string, conf types.DatabaseConfig) {
+	if len(args) < 1 || len(args) > 2 {
+		migrateUsageExit()
+	}
+
+	var number int64 = 0
+	switch args[0] {
+	case "up", "status":
+		if len(args) != 1 {
+			migrateUsageExit()
+		}
+	case "down", "baseline":
+		if len(args) == 2 {
+			n, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || n <= 0 {
+				migrateUsageExit()
+			}
+			number = n
+		}
+	default:
+		migrateUsageExit()
+	}
+
+	db := database.LoadDatabase(conf)
+	defer db.Close()
+	database.ConnectDatabase(db)
+
+	var err error
+	switch args[0] {
+	case "up":
+		err = database.Migrate(db)
+	case "down":
+		if number == 0 {
+			number = 1
+		}
+		err = database.MigrateDown(db, int(number))
+	case "status":
+		var statuses []types.MigrationStatus
+		var initialised bool
+		statuses, initialised, err = database.MigrationStatuses(db)
+		if err == nil {
+			if !initialised {
+				fmt.Println("Database is not initialised, every migration is pending.")
+			}
+			printMigrationStatuses(statuses)
+		}
+	case "baseline":
+		if number == 0 {
+			var statuses []types.MigrationStatus
+			statuses, _, err = database.MigrationStatuses(db)
+			for _, status := range statuses {
+				if status.Known {
+					number = status.Version
+				}
+			}
+		}
+		if err == nil {
+			err = database.MigrateBaseline(db, number)
+		}
+	}
+
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"stew/constants"
+	"stew/database"
+	"stew/types"
+	"strconv"
+)
+
+const snapshotsUsage = `Usage: %s snapshots <command>
+
+Commands:
+  prune [DAYS] Delete chat messages older than DAYS (default %d) that no snapshot refers to
+`
+
+func snapshotsUsageExit() {
+	fmt.Fprintf(os.Stderr, snapshotsUsage, os.Args[0], constants.SnapshotRetentionDays)
+	os.Exit(2)
+}
+
+func Snapshots(args []string, conf types.DatabaseConfig) {
+	if len(args) < 1 || len(args) > 2 || args[0] != "prune" {
+		snapshotsUsageExit()
+	}
+
+	days := constants.SnapshotRetentionDays
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			snapshotsUsageExit()
+		}
+		days = n
+	}
+
+	db := database.LoadDatabase(conf)
+	defer db.Close()
+	database.ConnectDatabase(db)
+
+	deleted, err := database.PruneSnapshotMessages(days)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Deleted %d messages\n", deleted)
+}
@@ -1,14 +1,18 @@
 package config
 
 import (
+	"github.com/sirupsen/logrus"
+	"stew/constants"
 	"stew/embeds"
+	"stew/logging"
 	"stew/types"
 	"strings"
 )
 
-func LoadConfig() (types.DatabaseConfig, types.APIConfig) {
+func LoadConfig() (types.DatabaseConfig, types.APIConfig, types.LogConfig) {
 	var db types.DatabaseConfig
 	var api types.APIConfig
+	var log types.LogConfig
 	db.SQLHost = readStr(key("SQL_HOST"), "127.0.0.1")
 	db.SQLPort = readUInt16(key("SQL_PORT"), 5432)
 	if db.SQLPort <= 1024 || db.SQLPort > 65535 {
@@ -23,6 +27,7 @@ func LoadConfig() (types.DatabaseConfig, types.APIConfig) {
 	if db.MaxConns < db.MinConns || db.MinConns <= 0 || db.MaxConns <= 0 {
 		panic("Illegal number of min/max SQL connections.")
 	}
+	db.MigrateOnStart = readBool(key("SQL_MIGRATE_ON_START"), true)
 
 	api.ListenAddress = readStr(key("LISTEN_ADDRESS"), "127.0.0.1")
 	api.ListenPort = readUInt16(key("LISTEN_PORT"), 8080)
@@ -30,5 +35,63 @@ func LoadConfig() (types.DatabaseConfig, types.APIConfig) {
 		panic("Illegal port number.")
 	}
 
-	return db, api
+	api.AuthEnabled = readBool(key("AUTH_ENABLED"), true)
+	api.APIKeyCacheSeconds = readInt32(key("AUTH_KEY_CACHE_SECONDS"), 30)
+	if api.APIKeyCacheSeconds < 0 {
+		panic("Illegal api key cache duration.")
+	}
+
+	api.ShutdownTimeoutSeconds = readInt32(key("SHUTDOWN_TIMEOUT_SECONDS"), 15)
+	if api.ShutdownTimeoutSeconds <= 0 {
+		panic("Illegal shutdown timeout.")
+	}
+
+	api.MaxBodyBytes = readInt32(key("MAX_BODY_BYTES"), 1<<20)
+	if api.MaxBodyBytes <= 0 {
+		panic("Illegal max body size.")
+	}
+
+	api.Network.FriendLimit = readInt32(key("FRIEND_LIMIT"), 100)
+	if api.Network.FriendLimit <= 0 {
+		panic("Illegal friend limit.")
+	}
+	api.Network.ThankCooldownSeconds = readInt32(key("THANK_COOLDOWN_SECONDS"), 3600)
+	if api.Network.ThankCooldownSeconds < 0 {
+		panic("Illegal thank cooldown.")
+	}
+	api.Network.KitLevelXp = readInt64List(key("KIT_LEVEL_XP"), []int64{100, 250, 450, 700, 1000, 1350, 1750, 2200, 2700, 3250})
+	for i, xp := range api.Network.KitLevelXp {
+		if xp <= 0 || (i > 0 && xp <= api.Network.KitLevelXp[i-1]) {
+			panic("Illegal kit level curve.")
+		}
+	}
+	api.Network.EloKFactor = readInt32(key("ELO_K_FACTOR"), 32)
+	if api.Network.EloKFactor <= 0 {
+		panic("Illegal elo k-factor.")
+	}
+	api.Network.EloInitialRating = readInt32(key("ELO_INITIAL_RATING"), 1000)
+	if api.Network.EloInitialRating < 0 {
+		panic("Illegal initial elo rating.")
+	}
+	api.Network.SnapshotRetentionDays = readInt32(key("SNAPSHOT_RETENTION_DAYS"), constants.SnapshotRetentionDays)
+	if api.Network.SnapshotRetentionDays < 0 {
+		panic("Illegal snapshot retention.")
+	}
+
+	log.Format = strings.ToLower(readStr(key("LOG_FORMAT"), logging.FormatText))
+	if log.Format != logging.FormatText && log.Format != logging.FormatJSON {
+		panic("Illegal log format.")
+	}
+	level, err := logrus.ParseLevel(readStr(key("LOG_LEVEL"), "info"))
+	if err != nil {
+		panic("Illegal log level.")
+	}
+	log.Level = level
+	log.RedactFields = append([]string{}, logging.DefaultRedactFields...)
+	redact := readStr(key("LOG_REDACT_FIELDS"), "")
+	if redact != "" {
+		log.RedactFields = append(log.RedactFields, strings.Split(redact, ",")...)
+	}
+
+	return db, api, log
 }
@@ -37,7 +37,7 @@ func parseUInt(key string, bitsize int) (uint64, error) {
 }
 
 func readUInt16(key string, fallback uint16) uint16 {
-	v, err := parseUInt(key, 16)
+	v, err := parseUInt(read(key, ""), 16)
 	if err != nil {
 		return fallback
 	}
@@ -45,7 +45,7 @@ func readUInt16(key string, fallback uint16) uint16 {
 }
 
 func readInt32(key string, fallback int32) int32 {
-	v, err := parseInt(key, 32)
+	v, err := parseInt(read(key, ""), 32)
 	if err != nil {
 		return fallback
 	}
@@ -59,3 +59,19 @@ func readBool(key string, fallback bool) bool {
 	}
 	return v
 }
+
+func readInt64List(key string, fallback []int64) []int64 {
+	v := read(key, "")
+	if v == "" {
+		return fallback
+	}
+	var res []int64
+	for _, part := range strings.Split(v, ",") {
+		num, err := parseInt(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fallback
+		}
+		res = append(res, num)
+	}
+	return res
+}
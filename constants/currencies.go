@@ -0,0 +1,10 @@
+package constants
+
+const (
+	CurrencyGems  = "gems"
+	CurrencyCoins = "coins"
+)
+
+func IsKnownCurrency(currency string) bool {
+	return currency == CurrencyGems || currency == CurrencyCoins
+}
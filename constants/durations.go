@@ -0,0 +1,4 @@
+package constants
+
+// Durations are given in hours; this one never expires.
+const DurationPermanent = -1
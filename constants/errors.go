@@ -0,0 +1,71 @@
+package constants
+
+const (
+	ErrorInvalidField     = "invalid_field"
+	ErrorMissingField     = "missing_field"
+	ErrorEmptyRequest     = "empty_request"
+	ErrorUnreadableBody   = "unreadable_body"
+	ErrorBodyTooLarge     = "body_too_large"
+	ErrorUnauthorized     = "unauthorized"
+	ErrorForbidden        = "forbidden"
+	ErrorNotFound         = "not_found"
+	ErrorMethodNotAllowed = "method_not_allowed"
+	ErrorInternal         = "internal_error"
+
+	ErrorAlreadyExists       = "already_exists"
+	ErrorUnknownReference    = "unknown_reference"
+	ErrorStillReferenced     = "still_referenced"
+	ErrorConstraintViolation = "constraint_violation"
+	ErrorValueOutOfRange     = "value_out_of_range"
+	ErrorDatabaseTimeout     = "database_timeout"
+	ErrorDatabaseUnavailable = "database_unavailable"
+
+	ErrorInsufficientFunds    = "insufficient_funds"
+	ErrorFriendRequestsClosed = "friend_requests_closed"
+	ErrorFriendLimitReached   = "friend_limit_reached"
+	ErrorPlayerBanned         = "player_banned"
+	ErrorIpBanned             = "ip_banned"
+	ErrorAltBanned            = "alt_banned"
+	ErrorPollClosed           = "poll_closed"
+	ErrorNotTeamMember        = "not_team_member"
+	ErrorReportUnavailable    = "report_unavailable"
+)
+
+var errorMessages = map[string]string{
+	ErrorInvalidField:     "Field has an invalid value.",
+	ErrorMissingField:     "Field is required.",
+	ErrorEmptyRequest:     "Request has no usable fields.",
+	ErrorUnreadableBody:   "Request body could not be read.",
+	ErrorBodyTooLarge:     "Request body is too large.",
+	ErrorUnauthorized:     "Missing or invalid API key.",
+	ErrorForbidden:        "API key lacks the scope for this route.",
+	ErrorNotFound:         "Not found.",
+	ErrorMethodNotAllowed: "Method not allowed.",
+	ErrorInternal:         "Internal server error.",
+
+	ErrorAlreadyExists:       "Entry already exists.",
+	ErrorUnknownReference:    "Referenced entry does not exist.",
+	ErrorStillReferenced:     "Entry is still referenced by other entries.",
+	ErrorConstraintViolation: "Value violates a constraint.",
+	ErrorValueOutOfRange:     "Value is out of range.",
+	ErrorDatabaseTimeout:     "Database did not answer in time.",
+	ErrorDatabaseUnavailable: "Database is unavailable.",
+
+	ErrorInsufficientFunds:    "Balance is too low for this transaction.",
+	ErrorFriendRequestsClosed: "Player does not accept friend requests.",
+	ErrorFriendLimitReached:   "Friend limit reached.",
+	ErrorPlayerBanned:         "Player is banned.",
+	ErrorIpBanned:             "Address is banned.",
+	ErrorAltBanned:            "A linked account is banned.",
+	ErrorPollClosed:           "Poll is not accepting answers.",
+	ErrorNotTeamMember:        "Player is not on the report's team.",
+	ErrorReportUnavailable:    "Report is closed or handled by someone else.",
+}
+
+func ErrorMessage(code string) string {
+	message, exists := errorMessages[code]
+	if !exists {
+		return errorMessages[ErrorInternal]
+	}
+	return message
+}
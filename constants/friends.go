@@ -0,0 +1,17 @@
+package constants
+
+const (
+	FriendStatusFriend   = "friend"
+	FriendStatusOutgoing = "outgoing"
+	FriendStatusIncoming = "incoming"
+)
+
+// Stored in accountFriendData.status.
+const (
+	FriendPrivacyOpen   = 0
+	FriendPrivacyClosed = 1
+)
+
+func IsKnownFriendPrivacy(privacy int) bool {
+	return privacy == FriendPrivacyOpen || privacy == FriendPrivacyClosed
+}
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	PunishmentSentenceBan     = "Ban"
+	PunishmentSentenceMute    = "Mute"
+	PunishmentSentenceWarning = "Warning"
+)
+
+func IsKnownPunishmentSentence(sentence string) bool {
+	return sentence == PunishmentSentenceBan || sentence == PunishmentSentenceMute || sentence == PunishmentSentenceWarning
+}
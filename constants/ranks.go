@@ -0,0 +1,4 @@
+package constants
+
+// Every player without an active primary group belongs to this one.
+const RankDefault = "PLAYER"
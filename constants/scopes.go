@@ -0,0 +1,38 @@
+package constants
+
+const (
+	ScopeGroupGateway = "gateway"
+	ScopeGroupNetwork = "network"
+
+	ScopeAccessRead  = "read"
+	ScopeAccessWrite = "write"
+	ScopeAccessAll   = "*"
+
+	ScopeAll = "*"
+)
+
+var knownScopes = map[string]struct{}{
+	ScopeAll: {},
+
+	ScopeGroupGateway + ":" + ScopeAccessRead:  {},
+	ScopeGroupGateway + ":" + ScopeAccessWrite: {},
+	ScopeGroupGateway + ":" + ScopeAccessAll:   {},
+
+	ScopeGroupNetwork + ":" + ScopeAccessRead:  {},
+	ScopeGroupNetwork + ":" + ScopeAccessWrite: {},
+	ScopeGroupNetwork + ":" + ScopeAccessAll:   {},
+}
+
+func IsKnownScope(scope string) bool {
+	_, exists := knownScopes[scope]
+	return exists
+}
+
+func ScopeAllows(scopes []string, group string, access string) bool {
+	for _, scope := range scopes {
+		if scope == ScopeAll || scope == group+":"+ScopeAccessAll || scope == group+":"+access {
+			return true
+		}
+	}
+	return false
+}
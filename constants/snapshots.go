@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+// Stored in snapshotTypes.
+const (
+	SnapshotTypeChat           = 1
+	SnapshotTypePrivateMessage = 2
+	SnapshotTypeParty          = 3
+)
+
+func IsKnownSnapshotType(snapshotType int) bool {
+	return snapshotType == SnapshotTypeChat || snapshotType == SnapshotTypePrivateMessage || snapshotType == SnapshotTypeParty
+}
+
+// Unreferenced chat messages older than this are pruned.
+const SnapshotRetentionDays = 30
+
+const SnapshotPruneInterval = time.Hour
@@ -0,0 +1,12 @@
+package constants
+
+// Leaderboard windows. Weekly and monthly values reset at the start of each ISO week and calendar month.
+const (
+	StatWindowAllTime = "all"
+	StatWindowWeekly  = "weekly"
+	StatWindowMonthly = "monthly"
+)
+
+func IsKnownStatWindow(window string) bool {
+	return window == StatWindowAllTime || window == StatWindowWeekly || window == StatWindowMonthly
+}
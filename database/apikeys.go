@@ -0,0 +1,91 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"stew/types"
+)
+
+const apiKeyPrefix = "stew_"
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Returns the plain key. Only its hash is stored, so it cannot be recovered later.
+func CreateAPIKey(name string, scopes []string) (string, error) {
+	id, err := gonanoid.Generate("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", 40)
+	if err != nil {
+		return "", err
+	}
+	key := apiKeyPrefix + id
+
+	ctx, cancel := SetTimeout(3)
+	defer cancel()
+
+	_, err = Pool.Exec(ctx, `INSERT INTO stew_auth.apiKeys ("name", "keyHash", "scopes") VALUES ($1, $2, $3);`,
+		name, HashAPIKey(key), scopes)
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func RevokeAPIKey(name string) (bool, error) {
+	ctx, cancel := SetTimeout(3)
+	defer cancel()
+
+	tag, err := Pool.Exec(ctx, `UPDATE stew_auth.apiKeys SET "revoked" = true WHERE "name" = $1 AND "revoked" = false;`, name)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func ListAPIKeys() ([]types.APIKey, error) {
+	ctx, cancel := SetTimeout(3)
+	defer cancel()
+
+	rows, err := Pool.Query(ctx, `SELECT "id", "name", "scopes", "createdTime", "revoked" FROM stew_auth.apiKeys ORDER BY "id";`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]types.APIKey, 0)
+	for rows.Next() {
+		var k types.APIKey
+		err = rows.Scan(&k.Id, &k.Name, &k.Scopes, &k.CreatedTime, &k.Revoked)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// Key hash to scopes, for every key that has not been revoked.
+func LoadActiveAPIKeys() (map[string][]string, error) {
+	ctx, cancel := SetTimeout(3)
+	defer cancel()
+
+	rows, err := Pool.Query(ctx, `SELECT "keyHash", "scopes" FROM stew_auth.apiKeys WHERE "revoked" = false;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[string][]string)
+	for rows.Next() {
+		var hash string
+		var scopes []string
+		err = rows.Scan(&hash, &scopes)
+		if err != nil {
+			return nil, err
+		}
+		keys[hash] = scopes
+	}
+	return keys, rows.Err()
+}
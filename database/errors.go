@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"regexp"
+	"stew/constants"
+	"stew/types"
+	"strings"
+)
+
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgStringDataRightTruncation = "22001"
+	pgNumericValueOutOfRange    = "22003"
+	pgInvalidTextRepresentation = "22P02"
+	pgNotNullViolation          = "23502"
+	pgForeignKeyViolation       = "23503"
+	pgUniqueViolation           = "23505"
+	pgCheckViolation            = "23514"
+	pgTooManyConnections        = "53300"
+	pgQueryCanceled             = "57014"
+	pgAdminShutdown             = "57P01"
+	pgCannotConnectNow          = "57P03"
+
+	pgConnectionExceptionClass = "08"
+)
+
+// Raised by our own functions in sql/migrations.
+const (
+	stewInsufficientFunds    = "ST001"
+	stewNotFound             = "ST002"
+	stewFriendRequestsClosed = "ST003"
+	stewFriendLimitReached   = "ST004"
+	stewInvalidValue         = "ST005"
+	stewPollClosed           = "ST006"
+	stewNotTeamMember        = "ST007"
+	stewReportUnavailable    = "ST008"
+)
+
+// Backstops for recordCurrencyChange, which checks balances itself before updating them.
+var balanceConstraints = map[string]bool{"accounts_gems_check": true, "accounts_coins_check": true}
+
+// Key ("playerUUID")=(...) is not present in table "playerinfo".
+var pgDetailKeyRe = regexp.MustCompile(`^Key \(\"?([^")]+)\"?\)=`)
+
+func errorColumn(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	match := pgDetailKeyRe.FindStringSubmatch(pgErr.Detail)
+	if match == nil || strings.Contains(match[1], ",") {
+		return ""
+	}
+	return match[1]
+}
+
+// Maps a query error to the error code the client should see; routes/utils picks the HTTP status.
+// Anything unrecognised is an internal error.
+func TranslateError(err error) types.DatabaseError {
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return types.DatabaseError{Code: constants.ErrorDatabaseTimeout}
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return types.DatabaseError{Code: constants.ErrorDatabaseUnavailable}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return types.DatabaseError{Code: constants.ErrorInternal}
+	}
+
+	column := errorColumn(pgErr)
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return types.DatabaseError{Code: constants.ErrorAlreadyExists, Column: column}
+	case pgForeignKeyViolation:
+		if strings.Contains(pgErr.Detail, "is still referenced") {
+			return types.DatabaseError{Code: constants.ErrorStillReferenced, Column: column}
+		}
+		return types.DatabaseError{Code: constants.ErrorUnknownReference, Column: column}
+	case pgCheckViolation, pgNotNullViolation:
+		if balanceConstraints[pgErr.ConstraintName] {
+			return types.DatabaseError{Code: constants.ErrorInsufficientFunds}
+		}
+		return types.DatabaseError{Code: constants.ErrorConstraintViolation, Column: column}
+	case pgStringDataRightTruncation, pgNumericValueOutOfRange:
+		return types.DatabaseError{Code: constants.ErrorValueOutOfRange, Column: column}
+	case pgInvalidTextRepresentation:
+		return types.DatabaseError{Code: constants.ErrorInvalidField, Column: column}
+	case pgQueryCanceled:
+		return types.DatabaseError{Code: constants.ErrorDatabaseTimeout}
+	case pgTooManyConnections, pgAdminShutdown, pgCannotConnectNow:
+		return types.DatabaseError{Code: constants.ErrorDatabaseUnavailable}
+	case stewInsufficientFunds:
+		return types.DatabaseError{Code: constants.ErrorInsufficientFunds}
+	case stewNotFound:
+		return types.DatabaseError{Code: constants.ErrorNotFound}
+	case stewFriendRequestsClosed:
+		return types.DatabaseError{Code: constants.ErrorFriendRequestsClosed}
+	case stewFriendLimitReached:
+		return types.DatabaseError{Code: constants.ErrorFriendLimitReached}
+	case stewInvalidValue:
+		return types.DatabaseError{Code: constants.ErrorInvalidField, Column: column}
+	case stewPollClosed:
+		return types.DatabaseError{Code: constants.ErrorPollClosed}
+	case stewNotTeamMember:
+		return types.DatabaseError{Code: constants.ErrorNotTeamMember}
+	case stewReportUnavailable:
+		return types.DatabaseError{Code: constants.ErrorReportUnavailable}
+	}
+	if strings.HasPrefix(pgErr.Code, pgConnectionExceptionClass) {
+		return types.DatabaseError{Code: constants.ErrorDatabaseUnavailable}
+	}
+	return types.DatabaseError{Code: constants.ErrorInternal}
+}
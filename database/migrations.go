@@ -0,0 +1,387 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io/fs"
+	"regexp"
+	"sort"
+	"stew/embeds"
+	"stew/logging"
+	"stew/types"
+	"strconv"
+	"time"
+)
+
+// "Stew" in ASCII, shared by every instance so only one of them migrates at a time.
+const migrationLockId int64 = 0x53746577
+
+const migrationTimeout = 300
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+func loadMigrations(migrationsFS fs.FS) ([]types.Migration, error) {
+	entries, err := fs.ReadDir(migrationsFS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*types.Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		migration, found := byVersion[version]
+		if !found {
+			migration = &types.Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d (%s, %s)", version, migration.Name, match[2])
+		}
+
+		script, err := fs.ReadFile(migrationsFS, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.UpScript = string(script)
+		} else {
+			migration.DownScript = string(script)
+		}
+	}
+
+	migrations := make([]types.Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpScript == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func ensureMigrationsTable(ctx context.Context, conn *pgxpool.Conn) error {
+	_, err := conn.Exec(ctx, `CREATE SCHEMA IF NOT EXISTS stew_meta;
+CREATE TABLE IF NOT EXISTS stew_meta.schemaMigrations
+(
+    "version"     BIGINT    NOT NULL,
+    "name"        TEXT      NOT NULL,
+    "appliedTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("version")
+);`)
+	return err
+}
+
+// Implemented by both the pool and a single acquired connection.
+type migrationQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func appliedMigrations(ctx context.Context, db migrationQuerier) (map[int64]time.Time, error) {
+	rows, err := db.Query(ctx, `SELECT "version", "appliedTime" FROM stew_meta.schemaMigrations;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedTime time.Time
+		err = rows.Scan(&version, &appliedTime)
+		if err != nil {
+			return nil, err
+		}
+		applied[version] = appliedTime
+	}
+	return applied, rows.Err()
+}
+
+// Like appliedMigrations, but reports a database without the bookkeeping table as not initialised instead of failing.
+// Never creates anything, so it works for read-only roles and does not wait for the advisory lock.
+func readAppliedMigrations(ctx context.Context, db migrationQuerier) (map[int64]time.Time, bool, error) {
+	initialised := false
+	err := db.QueryRow(ctx, `SELECT to_regclass('stew_meta.schemaMigrations') IS NOT NULL;`).Scan(&initialised)
+	if err != nil || !initialised {
+		return map[int64]time.Time{}, false, err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	return applied, true, err
+}
+
+func latestVersion(migrations []types.Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func checkNotAhead(migrations []types.Migration, applied map[int64]time.Time) error {
+	latest := latestVersion(migrations)
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("database schema version %d is newer than the latest known migration %d", version, latest)
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *pgxpool.Conn, migration types.Migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, migration.UpScript)
+	if err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO stew_meta.schemaMigrations ("version", "name") VALUES ($1, $2);`,
+		migration.Version, migration.Name)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func revertMigration(ctx context.Context, conn *pgxpool.Conn, migration types.Migration) error {
+	if migration.DownScript == "" {
+		return fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, migration.DownScript)
+	if err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM stew_meta.schemaMigrations WHERE "version" = $1;`, migration.Version)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Runs fn on a single connection holding the migration advisory lock, with the bookkeeping table in place.
+func withMigrationLock(db *pgxpool.Pool, fn func(ctx context.Context, conn *pgxpool.Conn, migrations []types.Migration, applied map[int64]time.Time) error) error {
+	migrations, err := loadMigrations(embeds.MigrationsFS)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := SetTimeout(migrationTimeout)
+	defer cancel()
+
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1);", migrationLockId)
+	if err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1);", migrationLockId)
+
+	err = ensureMigrationsTable(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, conn, migrations, applied)
+}
+
+// Applies every pending migration from embeds.MigrationsFS, each in its own transaction.
+// Refuses to touch the database if it has been migrated by a newer binary.
+func Migrate(db *pgxpool.Pool) error {
+	return withMigrationLock(db, func(ctx context.Context, conn *pgxpool.Conn, migrations []types.Migration, applied map[int64]time.Time) error {
+		err := checkNotAhead(migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, found := applied[migration.Version]; found {
+				continue
+			}
+			logging.AppLogger.Info(fmt.Sprintf("Applying migration %d_%s", migration.Version, migration.Name))
+			err = applyMigration(ctx, conn, migration)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reverts the latest `steps` applied migrations, newest first.
+func MigrateDown(db *pgxpool.Pool, steps int) error {
+	return withMigrationLock(db, func(ctx context.Context, conn *pgxpool.Conn, migrations []types.Migration, applied map[int64]time.Time) error {
+		err := checkNotAhead(migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := migrations[i]
+			if _, found := applied[migration.Version]; !found {
+				continue
+			}
+			logging.AppLogger.Info(fmt.Sprintf("Reverting migration %d_%s", migration.Version, migration.Name))
+			err = revertMigration(ctx, conn, migration)
+			if err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Records every migration up to and including `version` as applied without running it.
+// Meant for databases that were created by hand from the SQL scripts before migrations existed.
+func MigrateBaseline(db *pgxpool.Pool, version int64) error {
+	return withMigrationLock(db, func(ctx context.Context, conn *pgxpool.Conn, migrations []types.Migration, applied map[int64]time.Time) error {
+		known := false
+		for _, migration := range migrations {
+			if migration.Version == version {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown migration version %d", version)
+		}
+
+		for _, migration := range migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, found := applied[migration.Version]; found {
+				continue
+			}
+			logging.AppLogger.Info(fmt.Sprintf("Baselining migration %d_%s", migration.Version, migration.Name))
+			_, err := conn.Exec(ctx, `INSERT INTO stew_meta.schemaMigrations ("version", "name") VALUES ($1, $2);`,
+				migration.Version, migration.Name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Lists known and applied migrations without taking the advisory lock. The bool is false when the database has
+// never been migrated, in which case every known migration is pending.
+func MigrationStatuses(db *pgxpool.Pool) ([]types.MigrationStatus, bool, error) {
+	migrations, err := loadMigrations(embeds.MigrationsFS)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := SetTimeout(10)
+	defer cancel()
+
+	applied, initialised, err := readAppliedMigrations(ctx, db)
+	if err != nil {
+		return nil, false, err
+	}
+
+	statuses := make([]types.MigrationStatus, 0)
+	for _, migration := range migrations {
+		appliedTime, found := applied[migration.Version]
+		statuses = append(statuses, types.MigrationStatus{
+			Version:     migration.Version,
+			Name:        migration.Name,
+			Known:       true,
+			Applied:     found,
+			AppliedTime: appliedTime,
+		})
+		delete(applied, migration.Version)
+	}
+	for version, appliedTime := range applied {
+		statuses = append(statuses, types.MigrationStatus{Version: version, Applied: true, AppliedTime: appliedTime})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, initialised, nil
+}
+
+// Errors unless the database is at exactly the latest known migration.
+func CheckMigrations(db *pgxpool.Pool) error {
+	ctx, cancel := SetTimeout(10)
+	defer cancel()
+
+	state, err := CurrentMigrationState(ctx, db)
+	if err != nil {
+		return err
+	}
+	if !state.Initialised {
+		return fmt.Errorf("database schema is not initialised, run `migrate up` first")
+	}
+	if state.Unknown > 0 {
+		return fmt.Errorf("database schema version %d is newer than this binary", state.CurrentVersion)
+	}
+	if state.Pending > 0 {
+		return fmt.Errorf("%d migrations are pending, run `migrate up` first", state.Pending)
+	}
+	return nil
+}
+
+// Compares the bookkeeping table with the embedded migrations without taking the advisory lock.
+func CurrentMigrationState(ctx context.Context, db *pgxpool.Pool) (types.MigrationState, error) {
+	state := types.MigrationState{}
+	migrations, err := loadMigrations(embeds.MigrationsFS)
+	if err != nil {
+		return state, err
+	}
+	state.LatestVersion = latestVersion(migrations)
+
+	applied, initialised, err := readAppliedMigrations(ctx, db)
+	if err != nil {
+		return state, err
+	}
+	state.Initialised = initialised
+
+	for version := range applied {
+		if version > state.CurrentVersion {
+			state.CurrentVersion = version
+		}
+		if version > state.LatestVersion {
+			state.Unknown++
+		}
+	}
+	for _, migration := range migrations {
+		if _, found := applied[migration.Version]; !found {
+			state.Pending++
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"stew/logging"
+	"time"
+)
+
+// Deletes chat messages older than `days` that no snapshot refers to.
+func PruneSnapshotMessages(days int) (int64, error) {
+	ctx, cancel := SetTimeout(60)
+	defer cancel()
+
+	var deleted int64
+	err := Pool.QueryRow(ctx, "SELECT stew_accounts.pruneSnapshotMessages($1);", days).Scan(&deleted)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// Runs PruneSnapshotMessages every `interval` until the returned function is called.
+// Deleting is idempotent, so it is fine for every instance to do this.
+func PruneSnapshotMessagesEvery(interval time.Duration, days int) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				deleted, err := PruneSnapshotMessages(days)
+				if err != nil {
+					logging.AppLogger.WithError(err).Error("Error pruning chat messages!!!")
+				} else if deleted > 0 {
+					logging.AppLogger.Info(fmt.Sprintf("Pruned %d chat messages", deleted))
+				}
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
@@ -2,4 +2,6 @@
 
 cd /app
 
-./Stew
+./Stew migrate up || exit 1
+
+exec ./Stew
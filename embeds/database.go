@@ -1,4 +1,5 @@
 package embeds
 
-var PlayerStatsSQLScript string
-var AccountsSQLScript string
+import "io/fs"
+
+var MigrationsFS fs.FS
@@ -1,6 +1,11 @@
 package embeds
 
-func InitDBEmbed(playerStatsSQLScript string, accountsSQLScript string) {
-	PlayerStatsSQLScript = playerStatsSQLScript
-	AccountsSQLScript = accountsSQLScript
+import "io/fs"
+
+func InitDBEmbed(migrationsFS fs.FS, root string) {
+	sub, err := fs.Sub(migrationsFS, root)
+	if err != nil {
+		panic(err)
+	}
+	MigrationsFS = sub
 }
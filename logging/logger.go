@@ -1,11 +1,21 @@
 package logging
 
 import (
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"os"
+	"stew/types"
 	"time"
 )
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+const requestLoggerKey = "stew/logging.request"
+
 var AppLogger = logrus.New()
 
 func LoadLogger() {
@@ -20,3 +30,23 @@ func LoadLogger() {
 	AppLogger.Out = os.Stdout
 	AppLogger.Level = logrus.InfoLevel
 }
+
+func ApplyConfig(conf types.LogConfig) {
+	if conf.Format == FormatJSON {
+		AppLogger.Formatter = &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		}
+	}
+	AppLogger.Level = conf.Level
+	redactFields = toRedactSet(conf.RedactFields)
+}
+
+// Entry carrying the request id, so every line logged while handling a request can be correlated.
+func Request(c *gin.Context) *logrus.Entry {
+	if entry, found := c.Get(requestLoggerKey); found {
+		return entry.(*logrus.Entry)
+	}
+	entry := AppLogger.WithField("request_id", requestid.Get(c))
+	c.Set(requestLoggerKey, entry)
+	return entry
+}
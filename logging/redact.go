@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "REDACTED"
+
+var DefaultRedactFields = []string{"ip", "ipAddress", "password", "secretKey", "secret", "token", "key"}
+
+var redactFields = toRedactSet(DefaultRedactFields)
+
+func toRedactSet(fields []string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, field := range fields {
+		set[strings.ToLower(strings.TrimSpace(field))] = struct{}{}
+	}
+	return set
+}
+
+func isRedacted(field string) bool {
+	_, found := redactFields[strings.ToLower(field)]
+	return found
+}
+
+func RedactValues(values url.Values) string {
+	for field := range values {
+		if isRedacted(field) {
+			values[field] = []string{redactedValue}
+		}
+	}
+	return values.Encode()
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for field, value := range t {
+			if isRedacted(field) {
+				t[field] = redactedValue
+			} else {
+				t[field] = redactJSON(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = redactJSON(value)
+		}
+	}
+	return v
+}
+
+// Masks sensitive fields in form and JSON bodies. Anything else is summarised by its size only.
+func RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			return RedactValues(values)
+		}
+	case "application/json":
+		var v interface{}
+		if json.Unmarshal(body, &v) == nil {
+			redacted, err := json.Marshal(redactJSON(v))
+			if err == nil {
+				return string(redacted)
+			}
+		}
+	}
+	return fmt.Sprintf("<%d bytes>", len(body))
+}
@@ -1,25 +1,29 @@
 package main
 
 import (
-	_ "embed"
+	"embed"
+	"fmt"
 	"github.com/joho/godotenv"
+	"os"
+	"os/signal"
+	"stew/commands"
 	"stew/config"
+	"stew/constants"
 	"stew/database"
 	"stew/embeds"
 	"stew/logging"
 	"stew/router"
 	"stew/routes"
 	"stew/utils"
+	"syscall"
+	"time"
 )
 
-//go:embed sql/player_stats.sql
-var playerStatsSQLScript string
-
-//go:embed sql/accounts.sql
-var accountsSQLScript string
+//go:embed sql/migrations/*.sql
+var migrationsFS embed.FS
 
 func main() {
-	embeds.InitDBEmbed(playerStatsSQLScript, accountsSQLScript)
+	embeds.InitDBEmbed(migrationsFS, "sql/migrations")
 
 	logging.LoadLogger()
 
@@ -27,20 +31,70 @@ func main() {
 
 	godotenv.Load()
 	logging.AppLogger.Info("Loading config")
-	dbConf, apiConf := config.LoadConfig()
+	dbConf, apiConf, logConf := config.LoadConfig()
+	logging.ApplyConfig(logConf)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			commands.Migrate(os.Args[2:], dbConf)
+		case "apikey":
+			commands.APIKey(os.Args[2:], dbConf)
+		case "snapshots":
+			commands.Snapshots(os.Args[2:], dbConf)
+		default:
+			fmt.Fprintf(os.Stderr, "Usage: %s [migrate|apikey|snapshots <command>]\n", os.Args[0])
+			os.Exit(2)
+		}
+		return
+	}
 
 	logging.AppLogger.Info("Loading database pool")
 	db := database.LoadDatabase(dbConf)
 	database.ConnectDatabase(db)
 
+	var err error
+	if dbConf.MigrateOnStart {
+		logging.AppLogger.Info("Running database migrations")
+		err = database.Migrate(db)
+	} else {
+		logging.AppLogger.Info("Checking database migrations")
+		err = database.CheckMigrations(db)
+	}
+	if err != nil {
+		panic(err)
+	}
+
 	logging.AppLogger.Info("Loading router")
 	router.LoadRouter(apiConf)
 	routes.LoadRoutes(apiConf)
 
+	stopPruning := func() {}
+	if apiConf.Network.SnapshotRetentionDays > 0 {
+		stopPruning = database.PruneSnapshotMessagesEvery(constants.SnapshotPruneInterval, int(apiConf.Network.SnapshotRetentionDays))
+	}
+
 	logging.AppLogger.Info("Starting server")
-	listener := router.Serve(apiConf)
+	serveErr := router.Serve(apiConf)
+	logging.AppLogger.Info(fmt.Sprintf("Listening on %s:%d", router.ListenAddr, router.ListenPort))
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-signals:
+		logging.AppLogger.Info(fmt.Sprintf("Received %s, shutting down!", sig))
+	case err = <-serveErr:
+		logging.AppLogger.WithError(err).Error("Server stopped unexpectedly!!!")
+	}
+
+	logging.AppLogger.Info("Draining connections")
+	err = router.Shutdown(time.Duration(apiConf.ShutdownTimeoutSeconds) * time.Second)
+	if err != nil {
+		logging.AppLogger.WithError(err).Error("Error draining connections!!!")
+	}
+
+	stopPruning()
 
-	logging.AppLogger.Info("Shutting down!")
-	defer listener.Close()
-	defer db.Close()
+	logging.AppLogger.Info("Closing database pool")
+	db.Close()
 }
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"strconv"
+	"time"
+)
+
+const namespace = "stew"
+
+// Route label for requests that matched no registered route, so random paths cannot blow up cardinality.
+const unmatchedRoute = "unmatched"
+
+var Registry = prometheus.NewRegistry()
+
+var httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "http",
+	Name:      "requests_total",
+	Help:      "HTTP requests handled, by route template, method and status.",
+}, []string{"route", "method", "status"})
+
+var httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "HTTP request latency, by route template, method and status.",
+	Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+}, []string{"route", "method", "status"})
+
+var PlayerLogins = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "gateway",
+	Name:      "player_logins_total",
+	Help:      "Player logins recorded.",
+})
+
+var SessionsClosed = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "gateway",
+	Name:      "sessions_closed_total",
+	Help:      "Login sessions closed.",
+})
+
+var IpsRegistered = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "gateway",
+	Name:      "ips_registered_total",
+	Help:      "New IP addresses registered.",
+})
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		PlayerLogins,
+		SessionsClosed,
+		IpsRegistered,
+		poolCollector{},
+	)
+}
+
+func ObserveRequest(route string, method string, status int, latency time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	statusLabel := strconv.Itoa(status)
+	httpRequests.WithLabelValues(route, method, statusLabel).Inc()
+	httpRequestDuration.WithLabelValues(route, method, statusLabel).Observe(latency.Seconds())
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"stew/database"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc(namespace+"_db_pool_acquired_conns", "Connections currently acquired from the pool.", nil, nil)
+	poolIdleConns     = prometheus.NewDesc(namespace+"_db_pool_idle_conns", "Idle connections in the pool.", nil, nil)
+	poolTotalConns    = prometheus.NewDesc(namespace+"_db_pool_total_conns", "Total connections in the pool.", nil, nil)
+	poolMaxConns      = prometheus.NewDesc(namespace+"_db_pool_max_conns", "Maximum size of the pool.", nil, nil)
+	poolAcquires      = prometheus.NewDesc(namespace+"_db_pool_acquires_total", "Successful connection acquisitions.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc(namespace+"_db_pool_empty_acquires_total", "Acquisitions that had to wait for a connection.", nil, nil)
+	poolCanceled      = prometheus.NewDesc(namespace+"_db_pool_canceled_acquires_total", "Acquisitions canceled by their context.", nil, nil)
+	poolAcquireTime   = prometheus.NewDesc(namespace+"_db_pool_acquire_seconds_total", "Total time spent acquiring connections.", nil, nil)
+)
+
+// Reads database.Pool.Stat() on every scrape.
+type poolCollector struct{}
+
+func (poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConns
+	ch <- poolIdleConns
+	ch <- poolTotalConns
+	ch <- poolMaxConns
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceled
+	ch <- poolAcquireTime
+}
+
+func (poolCollector) Collect(ch chan<- prometheus.Metric) {
+	if database.Pool == nil {
+		return
+	}
+	stat := database.Pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireTime, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,67 @@
+package responses
+
+import (
+	"fmt"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/constants"
+	"stew/database"
+	"stew/types"
+)
+
+func ErrorResponse(c *gin.Context, status int, code string, field string, message string) {
+	if message == "" {
+		message = constants.ErrorMessage(code)
+	}
+	c.AbortWithStatusJSON(status, types.ErrorResponse{Error: types.ErrorBody{
+		Code:      code,
+		Field:     field,
+		Message:   message,
+		RequestId: requestid.Get(c),
+	}})
+}
+
+func InternalErrorResponse(c *gin.Context) {
+	ErrorResponse(c, http.StatusInternalServerError, constants.ErrorInternal, "", "")
+}
+
+func NotFoundResponse(c *gin.Context) {
+	ErrorResponse(c, http.StatusNotFound, constants.ErrorNotFound, "", "")
+}
+
+func MissingFieldResponse(c *gin.Context, field string) {
+	ErrorResponse(c, http.StatusBadRequest, constants.ErrorMissingField, field, fmt.Sprintf("Field %s is required.", field))
+}
+
+var databaseErrorStatus = map[string]int{
+	constants.ErrorInvalidField:         http.StatusBadRequest,
+	constants.ErrorNotTeamMember:        http.StatusForbidden,
+	constants.ErrorNotFound:             http.StatusNotFound,
+	constants.ErrorUnknownReference:     http.StatusNotFound,
+	constants.ErrorAlreadyExists:        http.StatusConflict,
+	constants.ErrorStillReferenced:      http.StatusConflict,
+	constants.ErrorInsufficientFunds:    http.StatusConflict,
+	constants.ErrorFriendRequestsClosed: http.StatusConflict,
+	constants.ErrorFriendLimitReached:   http.StatusConflict,
+	constants.ErrorPollClosed:           http.StatusConflict,
+	constants.ErrorReportUnavailable:    http.StatusConflict,
+	constants.ErrorConstraintViolation:  http.StatusUnprocessableEntity,
+	constants.ErrorValueOutOfRange:      http.StatusUnprocessableEntity,
+	constants.ErrorDatabaseUnavailable:  http.StatusServiceUnavailable,
+	constants.ErrorDatabaseTimeout:      http.StatusGatewayTimeout,
+}
+
+// `fields` renames database columns to the request field names the client sent, and may be nil.
+func DatabaseErrorResponse(c *gin.Context, err error, fields map[string]string) {
+	dbErr := database.TranslateError(err)
+	field := dbErr.Column
+	if name, found := fields[field]; found {
+		field = name
+	}
+	status, found := databaseErrorStatus[dbErr.Code]
+	if !found {
+		status = http.StatusInternalServerError
+	}
+	ErrorResponse(c, status, dbErr.Code, field, "")
+}
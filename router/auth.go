@@ -0,0 +1,105 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+	"net/http"
+	"stew/constants"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"strings"
+	"sync"
+	"time"
+)
+
+const AuthorizationHeader = "Authorization"
+const authorizationScheme = "Bearer "
+
+var authEnabled = true
+var apiKeyCacheTTL = 30 * time.Second
+
+// How long to wait before asking the database again after a failed reload.
+const apiKeyRetryDelay = 5 * time.Second
+
+var apiKeyCache = struct {
+	sync.RWMutex
+	keys     map[string][]string
+	loadTime time.Time
+}{}
+
+var apiKeyLoads singleflight.Group
+
+// Reloads the key cache once it is older than apiKeyCacheTTL, keeping the old keys if the database is unreachable.
+// Concurrent requests share a single reload, and none of them hold the cache lock while it runs.
+func apiKeyScopes(c *gin.Context, hash string) ([]string, bool) {
+	apiKeyCache.RLock()
+	keys := apiKeyCache.keys
+	stale := time.Since(apiKeyCache.loadTime) > apiKeyCacheTTL
+	apiKeyCache.RUnlock()
+
+	if stale {
+		loaded, _, _ := apiKeyLoads.Do("keys", func() (any, error) {
+			return reloadAPIKeys(c), nil
+		})
+		keys = loaded.(map[string][]string)
+	}
+	if keys == nil {
+		return nil, false
+	}
+	scopes, found := keys[hash]
+	return scopes, found
+}
+
+func reloadAPIKeys(c *gin.Context) map[string][]string {
+	keys, err := database.LoadActiveAPIKeys()
+
+	apiKeyCache.Lock()
+	defer apiKeyCache.Unlock()
+	if err != nil {
+		logging.Request(c).WithError(err).Error("Error loading api keys!!!")
+		apiKeyCache.loadTime = time.Now().Add(min(apiKeyRetryDelay, apiKeyCacheTTL) - apiKeyCacheTTL)
+	} else {
+		apiKeyCache.keys = keys
+		apiKeyCache.loadTime = time.Now()
+	}
+	return apiKeyCache.keys
+}
+
+func ReloadAPIKeys() {
+	apiKeyCache.Lock()
+	apiKeyCache.loadTime = time.Time{}
+	apiKeyCache.Unlock()
+}
+
+// Reads need `<group>:read`, everything else needs `<group>:write`.
+func RequireAPIKey(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authEnabled {
+			return
+		}
+
+		header := c.GetHeader(AuthorizationHeader)
+		if !strings.HasPrefix(header, authorizationScheme) {
+			c.Header("WWW-Authenticate", "Bearer")
+			responses.ErrorResponse(c, http.StatusUnauthorized, constants.ErrorUnauthorized, "", "")
+			return
+		}
+
+		scopes, found := apiKeyScopes(c, database.HashAPIKey(strings.TrimPrefix(header, authorizationScheme)))
+		if !found {
+			c.Header("WWW-Authenticate", "Bearer")
+			responses.ErrorResponse(c, http.StatusUnauthorized, constants.ErrorUnauthorized, "", "")
+			return
+		}
+
+		access := constants.ScopeAccessWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			access = constants.ScopeAccessRead
+		}
+		if !constants.ScopeAllows(scopes, group, access) {
+			responses.ErrorResponse(c, http.StatusForbidden, constants.ErrorForbidden, "", "")
+			return
+		}
+	}
+}
@@ -1,24 +1,46 @@
 package router
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net"
+	"net/http"
+	"stew/constants"
+	"stew/responses"
 	"stew/types"
+	"time"
 )
 
-func Serve(conf types.APIConfig) net.Listener {
+var Server *http.Server
+
+// Starts serving in the background. The returned channel receives an error if the server stops on its own.
+func Serve(conf types.APIConfig) <-chan error {
 	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", conf.ListenAddress, conf.ListenPort))
 	if err != nil {
 		panic(err)
 	}
-	err = Router.RunListener(listener)
-	if err != nil {
-		panic(err)
-	}
 	ListenAddr = listener.Addr().(*net.TCPAddr).IP.String()
 	ListenPort = listener.Addr().(*net.TCPAddr).Port
-	return listener
+
+	Server = &http.Server{Handler: Router}
+	serveErr := make(chan error, 1)
+	go func() {
+		err := Server.Serve(listener)
+		if !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+	return serveErr
+}
+
+// Stops accepting connections and waits up to `timeout` for in-flight requests to finish.
+func Shutdown(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return Server.Shutdown(ctx)
 }
 
 var Router *gin.Engine
@@ -29,6 +51,15 @@ func LoadRouter(conf types.APIConfig) {
 		gin.SetMode(ginMode)
 		Router = gin.New()
 	}
+	authEnabled = conf.AuthEnabled
+	apiKeyCacheTTL = time.Duration(conf.APIKeyCacheSeconds) * time.Second
+	maxBodyBytes = int64(conf.MaxBodyBytes)
+
 	Router.Use(addRequestIdHeader)
-	Router.Use(routerLogger, gin.Recovery())
+	Router.Use(routerLogger, gin.CustomRecovery(recoveryHandler))
+	Router.NoRoute(responses.NotFoundResponse)
+	Router.HandleMethodNotAllowed = true
+	Router.NoMethod(func(c *gin.Context) {
+		responses.ErrorResponse(c, http.StatusMethodNotAllowed, constants.ErrorMethodNotAllowed, "", "")
+	})
 }
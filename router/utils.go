@@ -2,14 +2,16 @@ package router
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/sirupsen/logrus"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"stew/constants"
 	"stew/logging"
+	"stew/metrics"
+	"stew/responses"
 	"time"
 )
 
@@ -23,33 +25,64 @@ func randoms() string {
 	return id
 }
 
+func recoveryHandler(c *gin.Context, err any) {
+	logging.Request(c).WithField("panic", err).Error("Recovered from panic!!!")
+	responses.InternalErrorResponse(c)
+}
+
 var addRequestIdHeader = requestid.New(requestid.WithGenerator(randoms), requestid.WithCustomHeaderStrKey(RequestIdHeader))
 
+const logBodyKey = "stew/router.logBody"
+
+var maxBodyBytes int64 = 1 << 20
+
+// Opts a route into request body logging. Sensitive fields are still redacted.
+func LogBody(c *gin.Context) {
+	c.Set(logBodyKey, true)
+}
+
 func routerLogger(c *gin.Context) {
 	startTime := time.Now()
 
-	bodyBuffer := &bytes.Buffer{}
-	reader := io.TeeReader(c.Request.Body, bodyBuffer)
-	bodyBytes, err := ioutil.ReadAll(reader)
-	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.Panic(err)
-		panic(err)
+	var bodyBytes []byte
+	var err error
+	if c.Request.ContentLength > maxBodyBytes {
+		responses.ErrorResponse(c, http.StatusRequestEntityTooLarge, constants.ErrorBodyTooLarge, "", "")
+	} else {
+		bodyBytes, err = io.ReadAll(io.LimitReader(c.Request.Body, maxBodyBytes+1))
+		if err != nil {
+			responses.ErrorResponse(c, http.StatusBadRequest, constants.ErrorUnreadableBody, "", "")
+			logging.Request(c).WithError(err).Warn("Error reading request body")
+		} else if int64(len(bodyBytes)) > maxBodyBytes {
+			responses.ErrorResponse(c, http.StatusRequestEntityTooLarge, constants.ErrorBodyTooLarge, "", "")
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
-	c.Request.Body = io.NopCloser(bodyBuffer)
 
 	c.Next()
 
 	latencyTime := time.Since(startTime)
+	metrics.ObserveRequest(c.FullPath(), c.Request.Method, c.Writer.Status(), latencyTime)
 
-	logging.AppLogger.Info(fmt.Sprintf(
-		"\033[34m%s \033[37m[\033[33m%s\033[37m] \033[36m%s \033[37m- \033[35m%s \033[37m%s - \033[34m%d\033[37m - \033[31m%s\033[0m",
-		latencyTime,
-		c.Request.Header.Get(RequestIdHeader),
-		c.ClientIP(),
-		c.Request.Method,
-		c.Request.URL.String(),
-		c.Writer.Status(),
-		string(bodyBytes),
-	))
+	size := c.Writer.Size()
+	if size < 0 {
+		size = 0
+	}
+	path := c.Request.URL.Path
+	if c.Request.URL.RawQuery != "" {
+		path += "?" + logging.RedactValues(c.Request.URL.Query())
+	}
+	fields := logrus.Fields{
+		"client_ip":  c.ClientIP(),
+		"method":     c.Request.Method,
+		"route":      c.FullPath(),
+		"path":       path,
+		"status":     c.Writer.Status(),
+		"latency_ms": float64(latencyTime.Microseconds()) / 1000,
+		"bytes":      size,
+	}
+	if c.GetBool(logBodyKey) {
+		fields["body"] = logging.RedactBody(c.ContentType(), bodyBytes)
+	}
+	logging.Request(c).WithFields(fields).Info("Request handled")
 }
@@ -0,0 +1,18 @@
+package health
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/types"
+)
+
+// Mounted at the root, outside the api key middleware, so probes need no credentials.
+const RouteGroup = ""
+
+var Routes = []types.APIRoute{
+	{LivenessPath, http.MethodGet, []gin.HandlerFunc{liveness}},
+	{LivenessPath, http.MethodHead, []gin.HandlerFunc{liveness}},
+	{ReadinessPath, http.MethodGet, []gin.HandlerFunc{readiness}},
+	{ReadinessPath, http.MethodHead, []gin.HandlerFunc{readiness}},
+	{MetricsPath, http.MethodGet, []gin.HandlerFunc{metricsHandler}},
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/embeds"
+	"stew/types"
+)
+
+const (
+	statusOk       = "ok"
+	statusDegraded = "degraded"
+)
+
+func liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, types.HealthResponse{Status: statusOk, Version: embeds.ExecutableVersion})
+}
+
+func readiness(c *gin.Context) {
+	ctx, cancel := database.SetTimeout(2)
+	defer cancel()
+
+	res := types.ReadinessResponse{Status: statusOk}
+	res.Database.Status = statusOk
+	res.Migrations.Status = statusOk
+
+	err := database.Pool.Ping(ctx)
+	if err != nil {
+		res.Database = types.ReadinessCheck{Status: statusDegraded, Error: err.Error()}
+	}
+
+	state, err := database.CurrentMigrationState(ctx, database.Pool)
+	res.Migrations.CurrentVersion = state.CurrentVersion
+	res.Migrations.LatestVersion = state.LatestVersion
+	res.Migrations.Pending = state.Pending
+	if err != nil {
+		res.Migrations.ReadinessCheck = types.ReadinessCheck{Status: statusDegraded, Error: err.Error()}
+	} else if !state.Initialised {
+		res.Migrations.ReadinessCheck = types.ReadinessCheck{Status: statusDegraded, Error: "schema not initialised"}
+	} else if state.Pending > 0 || state.Unknown > 0 {
+		res.Migrations.ReadinessCheck = types.ReadinessCheck{Status: statusDegraded, Error: fmt.Sprintf(
+			"schema at version %d, binary expects %d", state.CurrentVersion, state.LatestVersion)}
+	}
+
+	stat := database.Pool.Stat()
+	res.Pool = types.PoolStatsResponse{
+		AcquiredConns: stat.AcquiredConns(),
+		IdleConns:     stat.IdleConns(),
+		TotalConns:    stat.TotalConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+
+	status := http.StatusOK
+	if res.Database.Status != statusOk || res.Migrations.Status != statusOk {
+		res.Status = statusDegraded
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, res)
+}
+
+const LivenessPath = "/healthz"
+const ReadinessPath = "/readyz"
@@ -0,0 +1,11 @@
+package health
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"stew/metrics"
+)
+
+var metricsHandler = gin.WrapH(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+const MetricsPath = "/metrics"
@@ -2,7 +2,9 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"stew/constants"
 	"stew/router"
+	"stew/routes/health"
 	"stew/routes/v1/gateway"
 	"stew/routes/v1/network"
 	"stew/types"
@@ -16,6 +18,8 @@ func loadRoutes(routeGroup *gin.RouterGroup, routes []types.APIRoute) {
 
 // !!! INVOKE THIS AFTER LoadRouter !!!
 func LoadRoutes(conf types.APIConfig) {
-	loadRoutes(router.Router.Group(gateway.RouteGroup), gateway.Routes)
-	loadRoutes(router.Router.Group(network.RouteGroup), network.Routes)
+	loadRoutes(router.Router.Group(health.RouteGroup), health.Routes)
+	loadRoutes(router.Router.Group(gateway.RouteGroup, router.RequireAPIKey(constants.ScopeGroupGateway)), gateway.Routes)
+	network.LoadConfig(conf.Network)
+	loadRoutes(router.Router.Group(network.RouteGroup, router.RequireAPIKey(constants.ScopeGroupNetwork)), network.Routes)
 }
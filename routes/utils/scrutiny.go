@@ -1,17 +1,25 @@
 package utils
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net"
 	"net/http"
 	"regexp"
 	"stew/constants"
+	"stew/responses"
 	"stew/types"
 	"strconv"
+	"unicode/utf8"
 )
 
-func InputInvalidResponse(c *gin.Context) {
-	c.AbortWithStatus(http.StatusBadRequest)
+func InputInvalidResponse(c *gin.Context, field string) {
+	if field == "" {
+		responses.ErrorResponse(c, http.StatusBadRequest, constants.ErrorEmptyRequest, "", "")
+		return
+	}
+	responses.ErrorResponse(c, http.StatusBadRequest, constants.ErrorInvalidField, field, fmt.Sprintf("Field %s has an invalid value.", field))
 }
 
 func ValidateIPv4(ipString string, allowEmpty bool, ctx *gin.Context) bool {
@@ -99,6 +107,198 @@ func ValidateUUID(uuid string, allowEmpty bool, ctx *gin.Context) bool {
 	return false
 }
 
+func ValidatePositiveInt(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		num, err := strconv.ParseInt(v, 10, 64)
+		return err == nil && num > 0
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateNonNegativeInt(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		num, err := strconv.ParseInt(v, 10, 64)
+		return err == nil && num >= 0
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateInt(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateBool(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		_, err := strconv.ParseBool(v)
+		return err == nil
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateCurrency(currency string, allowEmpty bool, ctx *gin.Context) bool {
+	if currency != "" {
+		return constants.IsKnownCurrency(currency)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateServerName(server string, allowEmpty bool, ctx *gin.Context) bool {
+	if server != "" {
+		serverPattern := "^[a-zA-Z0-9_\\-]{1,30}$"
+		serverRe := regexp.MustCompile(serverPattern)
+		return serverRe.MatchString(server)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateIdempotencyKey(key string, allowEmpty bool, ctx *gin.Context) bool {
+	if key != "" {
+		keyPattern := "^[a-zA-Z0-9_\\-:.]{1,64}$"
+		keyRe := regexp.MustCompile(keyPattern)
+		return keyRe.MatchString(key)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateReason(reason string, allowEmpty bool, ctx *gin.Context) bool {
+	if reason != "" {
+		return utf8.RuneCountInString(reason) <= 255
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateFriendPrivacy(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		privacy, err := strconv.Atoi(v)
+		return err == nil && constants.IsKnownFriendPrivacy(privacy)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidatePunishmentSentence(sentence string, allowEmpty bool, ctx *gin.Context) bool {
+	if sentence != "" {
+		return constants.IsKnownPunishmentSentence(sentence)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidatePunishmentCategory(category string, allowEmpty bool, ctx *gin.Context) bool {
+	if category != "" {
+		categoryPattern := "^[a-zA-Z0-9_]{1,32}$"
+		categoryRe := regexp.MustCompile(categoryPattern)
+		return categoryRe.MatchString(category)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+// Hours, or -1 for a permanent punishment.
+func ValidateDurationHours(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		duration, err := strconv.ParseFloat(v, 64)
+		return err == nil && !math.IsInf(duration, 0) && (duration == constants.DurationPermanent || duration >= 0)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateRank(rank string, allowEmpty bool, ctx *gin.Context) bool {
+	if rank != "" {
+		rankPattern := "^[a-zA-Z0-9_]{1,10}$"
+		rankRe := regexp.MustCompile(rankPattern)
+		return rankRe.MatchString(rank)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidatePreferenceName(name string, allowEmpty bool, ctx *gin.Context) bool {
+	if name != "" {
+		namePattern := "^[a-zA-Z0-9_]{1,64}$"
+		nameRe := regexp.MustCompile(namePattern)
+		return nameRe.MatchString(name)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateStatName(name string, allowEmpty bool, ctx *gin.Context) bool {
+	if name != "" {
+		namePattern := "^[a-zA-Z0-9_.]{1,255}$"
+		nameRe := regexp.MustCompile(namePattern)
+		return nameRe.MatchString(name)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateStatWindow(window string, allowEmpty bool, ctx *gin.Context) bool {
+	if window != "" {
+		return constants.IsKnownStatWindow(window)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateSnapshotType(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		snapshotType, err := strconv.Atoi(v)
+		return err == nil && constants.IsKnownSnapshotType(snapshotType)
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidatePollAnswer(v string, allowEmpty bool, ctx *gin.Context) bool {
+	if v != "" {
+		answer, err := strconv.Atoi(v)
+		return err == nil && answer >= 1 && answer <= 4
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
+func ValidateText(text string, allowEmpty bool, ctx *gin.Context) bool {
+	if text != "" {
+		return utf8.RuneCountInString(text) <= 1024
+	} else if allowEmpty {
+		return true
+	}
+	return false
+}
+
 func GetQueryData(field string, ctx *gin.Context) string {
 	return ctx.Query(field)
 }
@@ -131,7 +331,7 @@ func ValidateAllData(fields []types.UnvalidatedField, ctx *gin.Context, allowAll
 			}
 		}
 		if allEmpty {
-			InputInvalidResponse(ctx)
+			InputInvalidResponse(ctx, "")
 			return false
 		}
 	}
@@ -144,7 +344,11 @@ func ValidateAllData(fields []types.UnvalidatedField, ctx *gin.Context, allowAll
 		if field.Validator != nil {
 			res := field.Validator(postData, field.AllowEmpty, ctx)
 			if !res {
-				InputInvalidResponse(ctx)
+				if postData == "" {
+					responses.MissingFieldResponse(ctx, field.Name)
+				} else {
+					InputInvalidResponse(ctx, field.Name)
+				}
 				return false
 			}
 		} else if field.AllowEmpty {
@@ -3,6 +3,7 @@ package gateway
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"stew/responses"
 	"stew/router"
 	"stew/routes/utils"
 	"stew/types"
@@ -83,9 +84,7 @@ func ipInfoValidator(ctx *gin.Context) string {
 
 var Routes = []types.APIRoute{
 	{"", http.MethodGet, []gin.HandlerFunc{
-		func(ctx *gin.Context) {
-			ctx.Status(http.StatusNotFound)
-		},
+		responses.NotFoundResponse,
 	}},
 	{PlayerInfoPath, http.MethodGet, []gin.HandlerFunc{
 		func(ctx *gin.Context) {
@@ -96,6 +95,7 @@ var Routes = []types.APIRoute{
 		},
 	}},
 	{PlayerInfoPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
 			res := playerInfoValidator(ctx, PlayerInfoPath, true, true, true)
 			if res != nil {
@@ -104,6 +104,7 @@ var Routes = []types.APIRoute{
 		},
 	}},
 	{PlayerInfoPath, http.MethodPatch, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
 			res := playerInfoValidator(ctx, PlayerInfoPath, true, true, true)
 			if res != nil {
@@ -120,6 +121,7 @@ var Routes = []types.APIRoute{
 		},
 	}},
 	{IpInfoPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
 			res := ipInfoValidator(ctx)
 			if res != "" {
@@ -128,6 +130,7 @@ var Routes = []types.APIRoute{
 		},
 	}},
 	{PlayerLoginPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
 			res := playerLoginInfoValidator(ctx)
 			if res != nil {
@@ -144,6 +147,7 @@ var Routes = []types.APIRoute{
 		},
 	}},
 	{SessionPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
 			res := playerInfoValidator(ctx, SessionPath, false, false, false, true)
 			if res != nil {
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"stew/database"
 	"stew/logging"
+	"stew/metrics"
+	"stew/responses"
 	"stew/types"
 )
 
@@ -14,10 +16,11 @@ func addIpInfo(ipString string, c *gin.Context) {
 
 	_, err := database.Pool.Exec(ctx, "SELECT stew_player_stats.add_ip_info($1);", ipString)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error adding ip info!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error adding ip info!!!")
 		return
 	}
+	metrics.IpsRegistered.Inc()
 
 	c.Status(http.StatusNoContent)
 }
@@ -28,8 +31,8 @@ func getIpInfo(ipString string, c *gin.Context) {
 
 	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_player_stats.get_ip_info($1);", ipString)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error getting ip info!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting ip info!!!")
 		return
 	}
 	defer exec.Close()
@@ -39,12 +42,17 @@ func getIpInfo(ipString string, c *gin.Context) {
 		var i types.IpInfoResponse
 		err = exec.Scan(&i.Id, nil)
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
-			logging.AppLogger.WithError(err).Error("Error forging ip info response!!!")
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging ip info response!!!")
 			return
 		}
 		res = append(res, i)
 	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting ip info!!!")
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"stew/database"
 	"stew/logging"
+	"stew/responses"
 	"stew/types"
 )
 
@@ -14,8 +15,8 @@ func addPlayerInfo(uuid string, name string, version string, c *gin.Context) {
 
 	_, err := database.Pool.Exec(ctx, "SELECT stew_player_stats.add_player_info($1, $2, $3);", uuid, name, version)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error adding player info!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error adding player info!!!")
 		return
 	}
 
@@ -29,20 +30,30 @@ func getPlayerInfo(uuid string, c *gin.Context) {
 	res := types.PlayerInfoResponse{}
 	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_player_stats.get_player_info($1);", uuid)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error getting player info!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting player info!!!")
 		return
 	}
 	defer exec.Close()
 
-	if exec.Next() {
+	found := exec.Next()
+	if found {
 		err = exec.Scan(&res.UUID, &res.Name, &res.Version)
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
-			logging.AppLogger.WithError(err).Error("Error forging player info response!!!")
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging player info response!!!")
 			return
 		}
 	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting player info!!!")
+		return
+	}
+	if !found {
+		responses.NotFoundResponse(c)
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
 
@@ -52,8 +63,8 @@ func updatePlayerInfo(uuid string, name string, version string, c *gin.Context)
 
 	_, err := database.Pool.Exec(ctx, "SELECT stew_player_stats.update_player_info($1, $2, $3);", uuid, name, version)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error updating player info!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error updating player info!!!")
 		return
 	}
 
@@ -1,22 +1,71 @@
 package gateway
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"net/http"
+	"stew/constants"
 	"stew/database"
 	"stew/logging"
+	"stew/metrics"
+	"stew/responses"
+	"time"
 )
 
+var loginFields = map[string]string{"playerUUID": "uuid", "ipInfoId": "ipid"}
+
+var loginBanCodes = map[string]string{
+	"player": constants.ErrorPlayerBanned,
+	"ip":     constants.ErrorIpBanned,
+	"alt":    constants.ErrorAltBanned,
+}
+
+// Answers 403 and returns false when the player, their address or a linked account has an active ban.
+func checkLoginBan(playerUUID string, ipId string, c *gin.Context) bool {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	var kind string
+	var reason string
+	var expires pgtype.Timestamp
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.getLoginBan($1, $2);", playerUUID, ipId).
+		Scan(&kind, &reason, &expires)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return true
+	}
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, loginFields)
+		logging.Request(c).WithError(err).Error("Error checking player ban!!!")
+		return false
+	}
+
+	message := fmt.Sprintf("Banned permanently: %s", reason)
+	if expires.Valid {
+		message = fmt.Sprintf("Banned until %s: %s", expires.Time.Format(time.RFC3339), reason)
+	}
+	responses.ErrorResponse(c, http.StatusForbidden, loginBanCodes[kind], "", message)
+	logging.Request(c).WithField("uuid", playerUUID).WithField("ban", kind).Info("Refused login of banned player.")
+	return false
+}
+
 func handlePlayerLogin(playerUUID string, ipId string, c *gin.Context) {
+	if !checkLoginBan(playerUUID, ipId, c) {
+		return
+	}
+
 	ctx, cancel := database.SetTimeout(3)
 	defer cancel()
 
 	_, err := database.Pool.Exec(ctx, "SELECT stew_player_stats.handle_player_logins($1, $2);", playerUUID, ipId)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error handling player login!!!")
+		responses.DatabaseErrorResponse(c, err, loginFields)
+		logging.Request(c).WithError(err).Error("Error handling player login!!!")
 		return
 	}
+	metrics.PlayerLogins.Inc()
 
 	c.Status(http.StatusNoContent)
 }
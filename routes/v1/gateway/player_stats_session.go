@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"stew/database"
 	"stew/logging"
+	"stew/metrics"
+	"stew/responses"
 	"stew/types"
 )
 
@@ -14,10 +16,11 @@ func updateLoginSession(id string, c *gin.Context) {
 
 	_, err := database.Pool.Exec(ctx, "SELECT stew_player_stats.update_login_session($1);", id)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error updating login session!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error updating login session!!!")
 		return
 	}
+	metrics.SessionsClosed.Inc()
 
 	c.Status(http.StatusNoContent)
 }
@@ -29,8 +32,8 @@ func getSessionId(uuid string, c *gin.Context) {
 	res := types.SessionIdResponse{}
 	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_player_stats.get_session_id($1);", uuid)
 	if err != nil {
-		c.AbortWithStatus(http.StatusInternalServerError)
-		logging.AppLogger.WithError(err).Error("Error getting login session id!!!")
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting login session id!!!")
 		return
 	}
 	defer exec.Close()
@@ -38,11 +41,16 @@ func getSessionId(uuid string, c *gin.Context) {
 	if exec.Next() {
 		err = exec.Scan(&res.Id, nil, nil, nil)
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
-			logging.AppLogger.WithError(err).Error("Error forging login session id response!!!")
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging login session id response!!!")
 			return
 		}
 	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting login session id!!!")
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
 
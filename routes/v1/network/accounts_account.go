@@ -0,0 +1,107 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+func scanAccount(exec pgx.Rows, res *types.AccountResponse) error {
+	return exec.Scan(&res.UUID, &res.Name, &res.Gems, &res.Coins, &res.LastLogin, &res.TotalPlayTime)
+}
+
+// Answers with the single account returned by `query`, or 404 if there is none.
+func respondAccount(query string, c *gin.Context, args ...any) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, query, args...)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting account!!!")
+		return
+	}
+	defer exec.Close()
+
+	if !exec.Next() {
+		if exec.Err() != nil {
+			responses.DatabaseErrorResponse(c, exec.Err(), nil)
+			logging.Request(c).WithError(exec.Err()).Error("Error getting account!!!")
+			return
+		}
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	res := types.AccountResponse{}
+	err = scanAccount(exec, &res)
+	if err != nil {
+		responses.InternalErrorResponse(c)
+		logging.Request(c).WithError(err).Error("Error forging account response!!!")
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func getAccount(uuid string, name string, c *gin.Context) {
+	if uuid != "" {
+		respondAccount("SELECT * FROM stew_accounts.getAccount($1);", c, uuid)
+	} else {
+		respondAccount("SELECT * FROM stew_accounts.getAccountByName($1);", c, name)
+	}
+}
+
+func createAccount(uuid string, name string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.createAccount($1, $2);", uuid, name)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error creating account!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Creates the account on first join, otherwise refreshes its name and last login.
+func joinAccount(uuid string, name string, c *gin.Context) {
+	respondAccount("SELECT * FROM stew_accounts.joinAccount($1, $2);", c, uuid, name)
+}
+
+// Runs a function with an `updated` OUT parameter and answers 404 when it touched no account.
+func updateAccount(query string, logMessage string, c *gin.Context, args ...any) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	updated := false
+	err := database.Pool.QueryRow(ctx, query, args...).Scan(&updated)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error(logMessage)
+		return
+	}
+	if !updated {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func renameAccount(uuid string, name string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.renameAccount($1, $2);", "Error renaming account!!!", c, uuid, name)
+}
+
+func addPlayTime(uuid string, minutes string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.addPlayTime($1, $2);", "Error adding play time!!!", c, uuid, minutes)
+}
+
+const AccountPath = "/account"
+const AccountJoinPath = AccountPath + "/join"
+const AccountPlayTimePath = AccountPath + "/playtime"
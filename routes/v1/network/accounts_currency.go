@@ -0,0 +1,113 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+	"strconv"
+)
+
+const currencyHistoryDefaultLimit = 50
+const currencyHistoryMaxLimit = 100
+
+// Optional fields are sent as NULL rather than an empty string.
+func nullable(v string) any {
+	if v == "" {
+		return nil
+	}
+	return v
+}
+
+func adjustCurrency(uuid string, currency string, amount string, reason string, server string, actor string, idempotencyKey string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.CurrencyChangeResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.adjustCurrency($1, $2, $3, $4, $5, $6, $7);",
+		uuid, currency, amount, reason, server, nullable(actor), nullable(idempotencyKey),
+	).Scan(&res.TransactionId, &res.Balance, &res.Replayed)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, map[string]string{"actorUUID": "actor"})
+		logging.Request(c).WithError(err).Error("Error adjusting currency!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func creditCurrency(uuid string, currency string, amount string, reason string, server string, actor string, idempotencyKey string, c *gin.Context) {
+	adjustCurrency(uuid, currency, amount, reason, server, actor, idempotencyKey, c)
+}
+
+func debitCurrency(uuid string, currency string, amount string, reason string, server string, actor string, idempotencyKey string, c *gin.Context) {
+	adjustCurrency(uuid, currency, "-"+amount, reason, server, actor, idempotencyKey, c)
+}
+
+func transferCurrency(from string, to string, currency string, amount string, reason string, server string, actor string, idempotencyKey string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.CurrencyTransferResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.transferCurrency($1, $2, $3, $4, $5, $6, $7, $8);",
+		from, to, currency, amount, reason, server, nullable(actor), nullable(idempotencyKey),
+	).Scan(&res.TransactionId, &res.FromBalance, &res.ToBalance, &res.Replayed)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, map[string]string{"actorUUID": "actor"})
+		logging.Request(c).WithError(err).Error("Error transferring currency!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getCurrencyHistory(uuid string, currency string, before string, limit string, c *gin.Context) {
+	rowLimit := currencyHistoryDefaultLimit
+	if limit != "" {
+		rowLimit, _ = strconv.Atoi(limit)
+		rowLimit = min(rowLimit, currencyHistoryMaxLimit)
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getCurrencyTransactions($1, $2, $3, $4);",
+		uuid, nullable(currency), nullable(before), rowLimit)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting currency history!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := types.CurrencyHistoryResponse{Transactions: []types.CurrencyTransactionResponse{}}
+	for exec.Next() {
+		row := types.CurrencyTransactionResponse{}
+		err = exec.Scan(&row.Id, &row.PlayerUUID, &row.Currency, &row.Amount, &row.Balance, &row.Reason,
+			&row.Server, &row.ActorUUID, &row.CounterpartyUUID, &row.IdempotencyKey, &row.Time)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging currency history response!!!")
+			return
+		}
+		res.Transactions = append(res.Transactions, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting currency history!!!")
+		return
+	}
+
+	if len(res.Transactions) == rowLimit {
+		res.NextBefore = &res.Transactions[len(res.Transactions)-1].Id
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+const CurrencyPath = AccountPath + "/currency"
+const CurrencyCreditPath = CurrencyPath + "/credit"
+const CurrencyDebitPath = CurrencyPath + "/debit"
+const CurrencyTransferPath = CurrencyPath + "/transfer"
+const CurrencyHistoryPath = CurrencyPath + "/history"
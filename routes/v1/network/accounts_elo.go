@@ -0,0 +1,172 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/routes/utils"
+	"stew/types"
+	"strconv"
+	"strings"
+)
+
+const eloOpponentsDefaultLimit = 10
+const eloOpponentsMaxLimit = 100
+
+var eloFields = map[string]string{"playerUUID": "uuid", "gameId": "game"}
+
+func getElo(uuid string, game string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.EloResponse{}
+	res.GameId, _ = strconv.ParseInt(game, 10, 64)
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.getElo($1, $2, $3);",
+		uuid, game, networkConf.EloInitialRating,
+	).Scan(&res.Elo, &res.Rated)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, eloFields)
+		logging.Request(c).WithError(err).Error("Error getting elo!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Reads the parallel `uuid`, `team` and `placement` form arrays, one entry per participant.
+// Every member of a team must carry the same placement, and at least two teams must take part.
+// Returns nil after answering 400.
+func matchParticipants(c *gin.Context) ([]string, []int32, []int32) {
+	players := c.PostFormArray("uuid")
+	teamValues := c.PostFormArray("team")
+	placementValues := c.PostFormArray("placement")
+	if len(players) == 0 {
+		responses.MissingFieldResponse(c, "uuid")
+		return nil, nil, nil
+	}
+	if len(teamValues) != len(players) {
+		utils.InputInvalidResponse(c, "team")
+		return nil, nil, nil
+	}
+	if len(placementValues) != len(players) {
+		utils.InputInvalidResponse(c, "placement")
+		return nil, nil, nil
+	}
+
+	seen := map[string]bool{}
+	teamPlacements := map[int64]int64{}
+	var teams, placements []int32
+	for i, player := range players {
+		if !utils.ValidateUUID(player, false, c) || seen[strings.ToLower(player)] {
+			utils.InputInvalidResponse(c, "uuid")
+			return nil, nil, nil
+		}
+		seen[strings.ToLower(player)] = true
+		// Both are sent as INT[], so they have to fit in an int32.
+		team, err := strconv.ParseInt(teamValues[i], 10, 32)
+		if err != nil || team <= 0 {
+			utils.InputInvalidResponse(c, "team")
+			return nil, nil, nil
+		}
+		placement, err := strconv.ParseInt(placementValues[i], 10, 32)
+		if err != nil || placement <= 0 {
+			utils.InputInvalidResponse(c, "placement")
+			return nil, nil, nil
+		}
+		if known, ok := teamPlacements[team]; ok && known != placement {
+			utils.InputInvalidResponse(c, "placement")
+			return nil, nil, nil
+		}
+		teamPlacements[team] = placement
+		teams = append(teams, int32(team))
+		placements = append(placements, int32(placement))
+	}
+	if len(teamPlacements) < 2 {
+		utils.InputInvalidResponse(c, "team")
+		return nil, nil, nil
+	}
+	return players, teams, placements
+}
+
+func recordEloMatch(game string, c *gin.Context) {
+	players, teams, placements := matchParticipants(c)
+	if players == nil {
+		return
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.recordEloMatch($1, $2, $3, $4, $5, $6);",
+		game, players, teams, placements, networkConf.EloKFactor, networkConf.EloInitialRating)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, eloFields)
+		logging.Request(c).WithError(err).Error("Error recording elo match!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.EloChangeResponse{}
+	for exec.Next() {
+		row := types.EloChangeResponse{}
+		err = exec.Scan(&row.UUID, &row.Team, &row.Placement, &row.PreviousElo, &row.Elo)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging elo match response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), eloFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error recording elo match!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getEloOpponents(game string, uuid string, rng string, limit string, c *gin.Context) {
+	rowLimit := eloOpponentsDefaultLimit
+	if limit != "" {
+		rowLimit, _ = strconv.Atoi(limit)
+		rowLimit = min(rowLimit, eloOpponentsMaxLimit)
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getEloOpponents($1, $2, $3, $4, $5);",
+		game, uuid, networkConf.EloInitialRating, rng, rowLimit)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, eloFields)
+		logging.Request(c).WithError(err).Error("Error getting elo opponents!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.EloOpponentResponse{}
+	for exec.Next() {
+		row := types.EloOpponentResponse{}
+		err = exec.Scan(&row.UUID, &row.Name, &row.Elo)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging elo opponents response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), eloFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting elo opponents!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const AccountEloPath = AccountPath + "/elo"
+const EloMatchPath = "/elo/matches"
+const EloOpponentsPath = "/elo/opponents"
@@ -0,0 +1,118 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+func getFriends(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.FriendsResponse{Friends: []types.FriendResponse{}}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.getFriendPrivacy($1);", uuid).Scan(&res.Privacy)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting friends!!!")
+		return
+	}
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getFriends($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting friends!!!")
+		return
+	}
+	defer exec.Close()
+
+	for exec.Next() {
+		friend := types.FriendResponse{}
+		err = exec.Scan(&friend.UUID, &friend.Name, &friend.Status, &friend.Favourite)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging friends response!!!")
+			return
+		}
+		res.Friends = append(res.Friends, friend)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting friends!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func sendFriendRequest(uuid string, target string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.FriendRequestResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.sendFriendRequest($1, $2, $3);",
+		uuid, target, networkConf.FriendLimit).Scan(&res.Status)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error sending friend request!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func acceptFriendRequest(uuid string, target string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.acceptFriendRequest($1, $2, $3);", "Error accepting friend request!!!", c,
+		uuid, target, networkConf.FriendLimit)
+}
+
+func declineFriendRequest(uuid string, target string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.declineFriendRequest($1, $2);", "Error declining friend request!!!", c, uuid, target)
+}
+
+func removeFriend(uuid string, target string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.removeFriend($1, $2);", "Error removing friend!!!", c, uuid, target)
+}
+
+func setFriendFavourite(uuid string, target string, favourite string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.setFriendFavourite($1, $2, $3);", "Error setting favourite friend!!!", c, uuid, target, favourite)
+}
+
+func getFriendPrivacy(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.FriendPrivacyResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.getFriendPrivacy($1);", uuid).Scan(&res.Privacy)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting friend privacy!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func setFriendPrivacy(uuid string, privacy string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setFriendPrivacy($1, $2);", uuid, privacy)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, map[string]string{"playerUUID": "uuid"})
+		logging.Request(c).WithError(err).Error("Error setting friend privacy!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+const FriendsPath = AccountPath + "/friends"
+const FriendRequestPath = FriendsPath + "/request"
+const FriendAcceptPath = FriendsPath + "/accept"
+const FriendDeclinePath = FriendsPath + "/decline"
+const FriendFavouritePath = FriendsPath + "/favourite"
+const FriendPrivacyPath = FriendsPath + "/privacy"
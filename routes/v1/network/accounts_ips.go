@@ -0,0 +1,151 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+func getAccountIps(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_player_stats.get_player_ips($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting account ips!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.AccountIpResponse{}
+	for exec.Next() {
+		row := types.AccountIpResponse{}
+		err = exec.Scan(&row.IpAddress, &row.FirstSeen, &row.LastSeen, &row.Logins)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging account ips response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting account ips!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getIpAccounts(ip string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_player_stats.get_ip_players($1);", ip)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting ip accounts!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.IpAccountResponse{}
+	for exec.Next() {
+		row := types.IpAccountResponse{}
+		err = exec.Scan(&row.UUID, &row.Name, &row.FirstSeen, &row.LastSeen, &row.Logins)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging ip accounts response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting ip accounts!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getIpBans(ip string, activeOnly string, c *gin.Context) {
+	if activeOnly == "" {
+		activeOnly = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getIpBans($1, $2);", ip, activeOnly)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting ip bans!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.IpBanResponse{}
+	for exec.Next() {
+		row := types.IpBanResponse{}
+		err = exec.Scan(&row.Id, &row.IpAddress, &row.Reason, &row.Time, &row.Duration, &row.AdminUUID, &row.Removed,
+			&row.ReasonOfRemoval, &row.RemoverAdminUUID, &row.RemovedTime, &row.Expires, &row.Active)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging ip bans response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting ip bans!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func addIpBan(ip string, reason string, duration string, admin string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.IpBanIdResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.addIpBan($1, $2, $3, $4);", ip, reason, duration, admin).Scan(&res.Id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, punishmentFields)
+		logging.Request(c).WithError(err).Error("Error adding ip ban!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func removeIpBan(id string, reason string, admin string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	updated := false
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.removeIpBan($1, $2, $3);", id, reason, admin).Scan(&updated)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, punishmentFields)
+		logging.Request(c).WithError(err).Error("Error removing ip ban!!!")
+		return
+	}
+	if !updated {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+const AccountIpsPath = AccountPath + "/ips"
+const IpPath = "/ip"
+const IpAccountsPath = IpPath + "/accounts"
+const IpBansPath = IpPath + "/bans"
+const IpBanRemovePath = IpBansPath + "/remove"
@@ -0,0 +1,117 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+var kitFields = map[string]string{"playerUUID": "uuid", "kitId": "kit"}
+
+// The XP needed for the next level, or nil once the curve is exhausted.
+func nextLevelXp(level int64) *int64 {
+	if level < 0 || level >= int64(len(networkConf.KitLevelXp)) {
+		return nil
+	}
+	xp := networkConf.KitLevelXp[level]
+	return &xp
+}
+
+func getKits(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getKits($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, kitFields)
+		logging.Request(c).WithError(err).Error("Error getting kits!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.KitResponse{}
+	for exec.Next() {
+		row := types.KitResponse{}
+		err = exec.Scan(&row.KitId, &row.Active, &row.Xp, &row.Level, &row.UpgradeLevel, &row.Stats)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging kits response!!!")
+			return
+		}
+		row.NextLevelXp = nextLevelXp(row.Level)
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), kitFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting kits!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func unlockKit(uuid string, kit string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.KitUnlockResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.unlockKit($1, $2);", uuid, kit).Scan(&res.Unlocked)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, kitFields)
+		logging.Request(c).WithError(err).Error("Error unlocking kit!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func setKitActive(uuid string, kit string, active string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.setKitActive($1, $2, $3);", "Error setting kit active!!!", c, uuid, kit, active)
+}
+
+func addKitXp(uuid string, kit string, amount string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.KitXpResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.addKitXp($1, $2, $3, $4);",
+		uuid, kit, amount, networkConf.KitLevelXp,
+	).Scan(&res.Xp, &res.Level, &res.PreviousLevel)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, kitFields)
+		logging.Request(c).WithError(err).Error("Error adding kit xp!!!")
+		return
+	}
+	res.NextLevelXp = nextLevelXp(res.Level)
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Every form field other than `uuid` and `kit` is a stat name mapped to the amount to add.
+func incrementKitStats(uuid string, kit string, c *gin.Context) {
+	names, amounts := statIncrements(c, "uuid", "kit")
+	if names == nil {
+		return
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.incrementKitStats($1, $2, $3, $4);", uuid, kit, names, amounts)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, kitFields)
+		logging.Request(c).WithError(err).Error("Error incrementing kit stats!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+const KitsPath = AccountPath + "/kits"
+const KitUnlockPath = KitsPath + "/unlock"
+const KitActivePath = KitsPath + "/active"
+const KitXpPath = KitsPath + "/xp"
+const KitStatsPath = KitsPath + "/stats"
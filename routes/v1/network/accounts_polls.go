@@ -0,0 +1,176 @@
+package network
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/routes/utils"
+	"stew/types"
+)
+
+var pollFields = map[string]string{"createdBy": "admin", "playerUUID": "uuid", "pollId": "id"}
+
+func scanPoll(row pgx.Row, res *types.PollResponse) error {
+	var answers [4]pgtype.Text
+	err := row.Scan(&res.Id, &res.Enabled, &res.Question, &answers[0], &answers[1], &answers[2], &answers[3],
+		&res.CoinReward, &res.DisplayType, &res.CreatedBy, &res.CreatedTime)
+	if err != nil {
+		return err
+	}
+	res.Answers = []string{}
+	for _, answer := range answers {
+		if answer.Valid {
+			res.Answers = append(res.Answers, answer.String)
+		}
+	}
+	return nil
+}
+
+func getPolls(c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getPolls();")
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting polls!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.PollResponse{}
+	for exec.Next() {
+		row := types.PollResponse{}
+		err = scanPoll(exec, &row)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging polls response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting polls!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func createPoll(question string, answerA string, answerB string, answerC string, answerD string, reward string, displayType string, admin string, c *gin.Context) {
+	if (answerC != "" && answerB == "") || (answerD != "" && answerC == "") {
+		utils.InputInvalidResponse(c, "answerB")
+		return
+	}
+	if reward == "" {
+		reward = "0"
+	}
+	if displayType == "" {
+		displayType = "0"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.PollIdResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.createPoll($1, $2, $3, $4, $5, $6, $7, $8);",
+		question, answerA, nullable(answerB), nullable(answerC), nullable(answerD), reward, displayType, nullable(admin),
+	).Scan(&res.Id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, pollFields)
+		logging.Request(c).WithError(err).Error("Error creating poll!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func setPollEnabled(id string, enabled string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.setPollEnabled($1, $2);", "Error enabling poll!!!", c, id, enabled)
+}
+
+// Answers 204 when the player has answered every enabled poll.
+func getNextPoll(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.PollResponse{}
+	err := scanPoll(database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.getNextPoll($1);", uuid), &res)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting next poll!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func answerPoll(uuid string, id string, answer string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.PollAnswerResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.answerPoll($1, $2, $3);", uuid, id, answer).
+		Scan(&res.Reward, &res.Coins)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, pollFields)
+		logging.Request(c).WithError(err).Error("Error answering poll!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getPollResults(id string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getPollResults($1);", id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting poll results!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := types.PollResultsResponse{Answers: []types.PollResultResponse{}}
+	for exec.Next() {
+		row := types.PollResultResponse{}
+		err = exec.Scan(&row.Answer, &row.Text, &row.Votes)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging poll results response!!!")
+			return
+		}
+		res.Total += row.Votes
+		res.Answers = append(res.Answers, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting poll results!!!")
+		return
+	}
+	if len(res.Answers) == 0 {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const PollsPath = "/polls"
+const PollEnabledPath = PollsPath + "/enabled"
+const PollResultsPath = PollsPath + "/results"
+const AccountPollsPath = AccountPath + "/polls"
+const AccountNextPollPath = AccountPollsPath + "/next"
+const AccountPollAnswerPath = AccountPollsPath + "/answer"
@@ -0,0 +1,131 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/routes/utils"
+	"stew/types"
+	"strconv"
+)
+
+func getPreferenceDefinitions(c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getPreferenceDefinitions();")
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting preference definitions!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.PreferenceDefinitionResponse{}
+	for exec.Next() {
+		row := types.PreferenceDefinitionResponse{}
+		err = exec.Scan(&row.Id, &row.Name, &row.Default, &row.Description)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging preference definitions response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting preference definitions!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func setPreferenceDefinition(id string, name string, def string, description string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setPreferenceDefinition($1, $2, $3, $4);", id, name, def, description)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error setting preference definition!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func getPreferences(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getPreferences($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting preferences!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.PreferenceResponse{}
+	for exec.Next() {
+		row := types.PreferenceResponse{}
+		err = exec.Scan(&row.Id, &row.Name, &row.Value, &row.IsDefault)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging preferences response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting preferences!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Every form field other than `uuid` is a preference name mapped to a boolean.
+func setPreferences(uuid string, c *gin.Context) {
+	var names []string
+	var values []bool
+	for name, value := range c.Request.PostForm {
+		if name == "uuid" {
+			continue
+		}
+		if !utils.ValidatePreferenceName(name, false, c) || len(value) != 1 {
+			utils.InputInvalidResponse(c, name)
+			return
+		}
+		b, err := strconv.ParseBool(value[0])
+		if err != nil {
+			utils.InputInvalidResponse(c, name)
+			return
+		}
+		names = append(names, name)
+		values = append(values, b)
+	}
+	if len(names) == 0 {
+		utils.InputInvalidResponse(c, "")
+		return
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setPreferences($1, $2, $3);", uuid, names, values)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, map[string]string{"playerUUID": "uuid"})
+		logging.Request(c).WithError(err).Error("Error setting preferences!!!")
+		return
+	}
+
+	getPreferences(uuid, c)
+}
+
+const PreferenceDefinitionsPath = "/preferences"
+const PreferencesPath = AccountPath + "/preferences"
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+var punishmentFields = map[string]string{"playerUUID": "uuid", "adminUUID": "admin", "removerAdminUUID": "admin"}
+
+func getPunishments(uuid string, activeOnly string, c *gin.Context) {
+	if activeOnly == "" {
+		activeOnly = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getPunishments($1, $2);", uuid, activeOnly)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting punishments!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.PunishmentResponse{}
+	for exec.Next() {
+		row := types.PunishmentResponse{}
+		err = exec.Scan(&row.Id, &row.PlayerUUID, &row.Category, &row.Sentence, &row.Reason, &row.Time, &row.Duration,
+			&row.AdminUUID, &row.Severity, &row.Removed, &row.ReasonOfRemoval, &row.RemoverAdminUUID, &row.RemovedTime,
+			&row.ExtendToAlts, &row.Expires, &row.Active)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging punishments response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting punishments!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func addPunishment(uuid string, category string, sentence string, reason string, duration string, admin string, severity string, alts string, c *gin.Context) {
+	if severity == "" {
+		severity = "1"
+	}
+	if alts == "" {
+		alts = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.PunishmentIdResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.addPunishment($1, $2, $3, $4, $5, $6, $7, $8);",
+		uuid, category, sentence, reason, duration, admin, severity, alts).Scan(&res.Id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, punishmentFields)
+		logging.Request(c).WithError(err).Error("Error adding punishment!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func removePunishment(id string, reason string, admin string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	updated := false
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.removePunishment($1, $2, $3);", id, reason, admin).Scan(&updated)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, punishmentFields)
+		logging.Request(c).WithError(err).Error("Error removing punishment!!!")
+		return
+	}
+	if !updated {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+const PunishmentsPath = AccountPath + "/punishments"
+const PunishmentRemovePath = PunishmentsPath + "/remove"
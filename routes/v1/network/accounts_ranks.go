@@ -0,0 +1,152 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+	"strings"
+)
+
+var rankFields = map[string]string{"playerUUID": "uuid", "grantedBy": "admin", "adminUUID": "admin"}
+
+func getRanks(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getRanks($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting ranks!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.RankResponse{}
+	for exec.Next() {
+		row := types.RankResponse{}
+		err = exec.Scan(&row.Rank, &row.PrimaryGroup, &row.GrantedTime, &row.GrantedBy, &row.Expires)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging ranks response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting ranks!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getRankMembers(rank string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getRankMembers($1);", strings.ToUpper(rank))
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting rank members!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.RankMemberResponse{}
+	for exec.Next() {
+		row := types.RankMemberResponse{}
+		err = exec.Scan(&row.UUID, &row.Name, &row.PrimaryGroup, &row.GrantedTime, &row.GrantedBy, &row.Expires)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging rank members response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting rank members!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getRankLog(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getRankLog($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, nil)
+		logging.Request(c).WithError(err).Error("Error getting rank log!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.RankLogResponse{}
+	for exec.Next() {
+		row := types.RankLogResponse{}
+		err = exec.Scan(&row.Id, &row.PlayerUUID, &row.Rank, &row.Action, &row.PrimaryGroup, &row.Expires, &row.AdminUUID, &row.Time)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging rank log response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), nil)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting rank log!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func grantRank(uuid string, rank string, primary string, duration string, admin string, c *gin.Context) {
+	if primary == "" {
+		primary = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.grantRank($1, $2, $3, $4, $5);",
+		uuid, strings.ToUpper(rank), primary, nullable(duration), admin)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, rankFields)
+		logging.Request(c).WithError(err).Error("Error granting rank!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func revokeRank(uuid string, rank string, admin string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	updated := false
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.revokeRank($1, $2, $3);", uuid, strings.ToUpper(rank), admin).Scan(&updated)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, rankFields)
+		logging.Request(c).WithError(err).Error("Error revoking rank!!!")
+		return
+	}
+	if !updated {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+const RanksPath = AccountPath + "/ranks"
+const RankRevokePath = RanksPath + "/revoke"
+const RankLogPath = RanksPath + "/log"
+const RankMembersPath = "/rank/members"
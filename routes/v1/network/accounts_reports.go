@@ -0,0 +1,198 @@
+package network
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+var reportFields = map[string]string{
+	"playerUUID": "uuid", "teamId": "team", "categoryId": "category", "resultTypeId": "result",
+	"reporterUUID": "reporter", "suspectUUID": "suspect", "handlerUUID": "handler", "reportId": "id",
+}
+
+func setReportTeam(id string, name string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setReportTeam($1, $2);", id, name)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error setting report team!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func setReportCategory(id string, name string, team string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setReportCategory($1, $2, $3);", id, name, team)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error setting report category!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func setReportResultType(id string, name string, globalStat string, c *gin.Context) {
+	if globalStat == "" {
+		globalStat = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setReportResultType($1, $2, $3);", id, name, globalStat)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error setting report result type!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func setReportTeamMember(uuid string, team string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.setReportTeamMember($1, $2);", uuid, team)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error setting report team member!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func removeReportTeamMember(uuid string, c *gin.Context) {
+	updateAccount("SELECT stew_accounts.removeReportTeamMember($1);", "Error removing report team member!!!", c, uuid)
+}
+
+func fileReport(reporter string, suspect string, category string, reason string, server string, weight string, c *gin.Context) {
+	if weight == "" {
+		weight = "1"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.FileReportResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.fileReport($1, $2, $3, $4, $5, $6);",
+		reporter, suspect, category, reason, server, weight,
+	).Scan(&res.Id, &res.Merged, &res.Weight)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error filing report!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func scanReport(row pgx.Row, report *types.ReportResponse) error {
+	return row.Scan(&report.Id, &report.SuspectUUID, &report.SuspectName, &report.CategoryId, &report.TeamId,
+		&report.Weight, &report.Reporters, &report.SnapshotId, &report.HandlerUUID, &report.CreatedTime)
+}
+
+func getOpenReports(team string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getOpenReports($1, NULL);", nullable(team))
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error getting open reports!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.ReportResponse{}
+	for exec.Next() {
+		row := types.ReportResponse{}
+		err = scanReport(exec, &row)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging open reports response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), reportFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting open reports!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Answers 204 when the handler's team queue is empty.
+func claimReport(handler string, id string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.ReportResponse{}
+	err := scanReport(database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.claimReport($1, $2);", handler, nullable(id)), &res)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error claiming report!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func abortReport(handler string, id string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.abortReport($1, $2);", handler, id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error aborting report!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func closeReport(handler string, id string, result string, reason string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.ReportResultResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.closeReport($1, $2, $3, $4);", handler, id, result, reason).
+		Scan(&res.ResultId)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, reportFields)
+		logging.Request(c).WithError(err).Error("Error closing report!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const ReportsPath = "/reports"
+const ReportClaimPath = ReportsPath + "/claim"
+const ReportAbortPath = ReportsPath + "/abort"
+const ReportClosePath = ReportsPath + "/close"
+const ReportTeamsPath = ReportsPath + "/teams"
+const ReportTeamMembersPath = ReportTeamsPath + "/members"
+const ReportCategoriesPath = ReportsPath + "/types/categories"
+const ReportResultTypesPath = ReportsPath + "/types/results"
@@ -0,0 +1,172 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/routes/utils"
+	"stew/types"
+	"strconv"
+	"strings"
+)
+
+const snapshotMaxBatch = 500
+const snapshotDefaultMinutes = 30
+const snapshotMaxMinutes = 24 * 60
+const snapshotDefaultLimit = 100
+const snapshotMaxLimit = 500
+
+var snapshotFields = map[string]string{
+	"senderUUID": "sender", "recipientUUID": "recipients", "creatorUUID": "creator", "snapshotType": "type",
+}
+
+// Reads the parallel `sender`, `message`, `type` and `recipients` form arrays, plus `time` in unix milliseconds
+// when the server sends it. `recipients` is a comma separated list of UUIDs and may be empty.
+func addSnapshotMessages(server string, c *gin.Context) {
+	senders := c.PostFormArray("sender")
+	messages := c.PostFormArray("message")
+	typeValues := c.PostFormArray("type")
+	recipients := c.PostFormArray("recipients")
+	timeValues := c.PostFormArray("time")
+	if len(senders) == 0 {
+		responses.MissingFieldResponse(c, "sender")
+		return
+	}
+	if len(senders) > snapshotMaxBatch {
+		utils.InputInvalidResponse(c, "sender")
+		return
+	}
+	if len(messages) != len(senders) {
+		utils.InputInvalidResponse(c, "message")
+		return
+	}
+	if len(typeValues) != len(senders) {
+		utils.InputInvalidResponse(c, "type")
+		return
+	}
+	if len(recipients) != len(senders) {
+		utils.InputInvalidResponse(c, "recipients")
+		return
+	}
+	if len(timeValues) != 0 && len(timeValues) != len(senders) {
+		utils.InputInvalidResponse(c, "time")
+		return
+	}
+
+	var snapshotTypes []int16
+	var times []int64
+	for i := range senders {
+		if !utils.ValidateUUID(senders[i], false, c) {
+			utils.InputInvalidResponse(c, "sender")
+			return
+		}
+		if !utils.ValidateText(messages[i], false, c) {
+			utils.InputInvalidResponse(c, "message")
+			return
+		}
+		if !utils.ValidateSnapshotType(typeValues[i], false, c) {
+			utils.InputInvalidResponse(c, "type")
+			return
+		}
+		if recipients[i] != "" {
+			for _, recipient := range strings.Split(recipients[i], ",") {
+				if !utils.ValidateUUID(recipient, false, c) {
+					utils.InputInvalidResponse(c, "recipients")
+					return
+				}
+			}
+		}
+		snapshotType, _ := strconv.Atoi(typeValues[i])
+		snapshotTypes = append(snapshotTypes, int16(snapshotType))
+		if len(timeValues) != 0 {
+			if !utils.ValidatePositiveInt(timeValues[i], false, c) {
+				utils.InputInvalidResponse(c, "time")
+				return
+			}
+			t, _ := strconv.ParseInt(timeValues[i], 10, 64)
+			times = append(times, t)
+		}
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.SnapshotMessagesAddedResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.addSnapshotMessages($1, $2, $3, $4, $5, $6);",
+		server, senders, times, messages, snapshotTypes, recipients,
+	).Scan(&res.Added)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, snapshotFields)
+		logging.Request(c).WithError(err).Error("Error adding snapshot messages!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func createReportSnapshot(id string, creator string, minutes string, limit string, c *gin.Context) {
+	windowMinutes := snapshotDefaultMinutes
+	if minutes != "" {
+		windowMinutes, _ = strconv.Atoi(minutes)
+		windowMinutes = min(windowMinutes, snapshotMaxMinutes)
+	}
+	rowLimit := snapshotDefaultLimit
+	if limit != "" {
+		rowLimit, _ = strconv.Atoi(limit)
+		rowLimit = min(rowLimit, snapshotMaxLimit)
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.SnapshotCreatedResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.createReportSnapshot($1, $2, $3, $4);",
+		id, creator, windowMinutes, rowLimit,
+	).Scan(&res.Id, &res.Messages)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, snapshotFields)
+		logging.Request(c).WithError(err).Error("Error creating report snapshot!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getSnapshotMessages(id string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getSnapshotMessages($1);", id)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, snapshotFields)
+		logging.Request(c).WithError(err).Error("Error getting snapshot messages!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.SnapshotMessageResponse{}
+	for exec.Next() {
+		row := types.SnapshotMessageResponse{}
+		err = exec.Scan(&row.Id, &row.SenderUUID, &row.SenderName, &row.Server, &row.Time, &row.Message, &row.Type,
+			&row.Recipients)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging snapshot messages response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), snapshotFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting snapshot messages!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const SnapshotsPath = "/snapshots"
+const SnapshotMessagesPath = SnapshotsPath + "/messages"
+const ReportSnapshotPath = ReportsPath + "/snapshot"
@@ -0,0 +1,199 @@
+package network
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"net/http"
+	"slices"
+	"stew/constants"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/routes/utils"
+	"stew/types"
+	"strconv"
+)
+
+const leaderboardDefaultLimit = 10
+const leaderboardMaxLimit = 100
+const leaderboardDefaultRange = 5
+const leaderboardMaxRange = 50
+
+var statFields = map[string]string{"playerUUID": "uuid"}
+
+// Reads every form field not in `skip` as a stat name mapped to the amount to add.
+// Returns nil after answering 400.
+func statIncrements(c *gin.Context, skip ...string) ([]string, []int64) {
+	var names []string
+	var amounts []int64
+	for name, value := range c.Request.PostForm {
+		if slices.Contains(skip, name) {
+			continue
+		}
+		if !utils.ValidateStatName(name, false, c) || len(value) != 1 {
+			utils.InputInvalidResponse(c, name)
+			return nil, nil
+		}
+		amount, err := strconv.ParseInt(value[0], 10, 64)
+		if err != nil {
+			utils.InputInvalidResponse(c, name)
+			return nil, nil
+		}
+		names = append(names, name)
+		amounts = append(amounts, amount)
+	}
+	if len(names) == 0 {
+		utils.InputInvalidResponse(c, "")
+		return nil, nil
+	}
+	return names, amounts
+}
+
+func statWindow(window string) string {
+	if window == "" {
+		return constants.StatWindowAllTime
+	}
+	return window
+}
+
+// Every form field other than `uuid` is a stat name mapped to the amount to add.
+func incrementStats(uuid string, c *gin.Context) {
+	names, amounts := statIncrements(c, "uuid")
+	if names == nil {
+		return
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	_, err := database.Pool.Exec(ctx, "SELECT stew_accounts.incrementStats($1, $2, $3);", uuid, names, amounts)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, statFields)
+		logging.Request(c).WithError(err).Error("Error incrementing stats!!!")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func getStats(uuid string, window string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getStats($1, $2);", uuid, statWindow(window))
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, statFields)
+		logging.Request(c).WithError(err).Error("Error getting stats!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.StatResponse{}
+	for exec.Next() {
+		row := types.StatResponse{}
+		err = exec.Scan(&row.Name, &row.Value)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging stats response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), statFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting stats!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func respondLeaderboard(c *gin.Context, notFoundIfEmpty bool, query string, args ...any) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, query, args...)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, statFields)
+		logging.Request(c).WithError(err).Error("Error getting leaderboard!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.LeaderboardEntryResponse{}
+	for exec.Next() {
+		row := types.LeaderboardEntryResponse{}
+		err = exec.Scan(&row.Rank, &row.UUID, &row.Name, &row.Value)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging leaderboard response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), statFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting leaderboard!!!")
+		return
+	}
+	if notFoundIfEmpty && len(res) == 0 {
+		responses.NotFoundResponse(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func getStatLeaderboard(stat string, window string, limit string, offset string, c *gin.Context) {
+	rowLimit := leaderboardDefaultLimit
+	if limit != "" {
+		rowLimit, _ = strconv.Atoi(limit)
+		rowLimit = min(rowLimit, leaderboardMaxLimit)
+	}
+	rowOffset := 0
+	if offset != "" {
+		rowOffset, _ = strconv.Atoi(offset)
+	}
+
+	respondLeaderboard(c, false, "SELECT * FROM stew_accounts.getStatLeaderboard($1, $2, $3, $4);",
+		stat, statWindow(window), rowLimit, rowOffset)
+}
+
+// Answers 404 when the player has no value for the stat in this window.
+func getStatRank(stat string, window string, uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.LeaderboardEntryResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.getStatAround($1, $2, $3, 0);", stat, statWindow(window), uuid).
+		Scan(&res.Rank, &res.UUID, &res.Name, &res.Value)
+	if errors.Is(err, pgx.ErrNoRows) {
+		responses.NotFoundResponse(c)
+		return
+	}
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, statFields)
+		logging.Request(c).WithError(err).Error("Error getting stat rank!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Answers 404 when the player has no value for the stat in this window.
+func getStatAround(stat string, window string, uuid string, rng string, c *gin.Context) {
+	rowRange := leaderboardDefaultRange
+	if rng != "" {
+		rowRange, _ = strconv.Atoi(rng)
+		rowRange = min(rowRange, leaderboardMaxRange)
+	}
+
+	respondLeaderboard(c, true, "SELECT * FROM stew_accounts.getStatAround($1, $2, $3, $4);",
+		stat, statWindow(window), uuid, rowRange)
+}
+
+const StatsPath = AccountPath + "/stats"
+const LeaderboardPath = "/stats/leaderboard"
+const LeaderboardRankPath = LeaderboardPath + "/rank"
+const LeaderboardAroundPath = LeaderboardPath + "/around"
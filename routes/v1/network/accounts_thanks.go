@@ -0,0 +1,68 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+var thankFields = map[string]string{"receiverUUID": "receiver", "senderUUID": "sender", "playerUUID": "uuid"}
+
+func addThank(receiver string, sender string, amount string, reason string, ignoreCooldown string, c *gin.Context) {
+	if ignoreCooldown == "" {
+		ignoreCooldown = "false"
+	}
+
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.ThankResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.addThank($1, $2, $3, $4, $5, $6);",
+		receiver, sender, amount, reason, ignoreCooldown, networkConf.ThankCooldownSeconds,
+	).Scan(&res.Success, &res.CooldownRemaining)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, thankFields)
+		logging.Request(c).WithError(err).Error("Error adding thank!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func checkAmplifierThank(uuid string, amplifierId string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.AmplifierThankResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT stew_accounts.checkAmplifierThank($1, $2);", uuid, amplifierId).Scan(&res.CanThank)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, thankFields)
+		logging.Request(c).WithError(err).Error("Error checking amplifier thank!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func claimThank(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.ClaimThankResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.claimThank($1);", uuid).
+		Scan(&res.AmountClaimed, &res.UniqueThank, &res.Coins)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, thankFields)
+		logging.Request(c).WithError(err).Error("Error claiming thanks!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const ThanksPath = AccountPath + "/thanks"
+const ThankAmplifierPath = ThanksPath + "/amplifier"
+const ThankClaimPath = ThanksPath + "/claim"
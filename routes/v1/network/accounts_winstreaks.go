@@ -0,0 +1,62 @@
+package network
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stew/database"
+	"stew/logging"
+	"stew/responses"
+	"stew/types"
+)
+
+var winstreakFields = map[string]string{"playerUUID": "uuid", "gameId": "game"}
+
+func getWinstreaks(uuid string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	exec, err := database.Pool.Query(ctx, "SELECT * FROM stew_accounts.getWinstreaks($1);", uuid)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, winstreakFields)
+		logging.Request(c).WithError(err).Error("Error getting win streaks!!!")
+		return
+	}
+	defer exec.Close()
+
+	res := []types.WinstreakResponse{}
+	for exec.Next() {
+		row := types.WinstreakResponse{}
+		err = exec.Scan(&row.GameId, &row.Streak, &row.Best)
+		if err != nil {
+			responses.InternalErrorResponse(c)
+			logging.Request(c).WithError(err).Error("Error forging win streaks response!!!")
+			return
+		}
+		res = append(res, row)
+	}
+	if exec.Err() != nil {
+		responses.DatabaseErrorResponse(c, exec.Err(), winstreakFields)
+		logging.Request(c).WithError(exec.Err()).Error("Error getting win streaks!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func reportWinstreakResult(uuid string, game string, won string, c *gin.Context) {
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+
+	res := types.WinstreakResultResponse{}
+	err := database.Pool.QueryRow(ctx, "SELECT * FROM stew_accounts.reportWinstreakResult($1, $2, $3);", uuid, game, won).
+		Scan(&res.Streak, &res.PreviousStreak, &res.Best, &res.NewBest)
+	if err != nil {
+		responses.DatabaseErrorResponse(c, err, winstreakFields)
+		logging.Request(c).WithError(err).Error("Error reporting win streak result!!!")
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const WinstreaksPath = AccountPath + "/winstreaks"
@@ -3,16 +3,880 @@ package network
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"stew/responses"
 	"stew/router"
+	"stew/routes/utils"
 	"stew/types"
+	"strings"
 )
 
 const RouteGroup = router.V1RootRouteGroup + "/network"
 
+var networkConf types.NetworkConfig
+
+func LoadConfig(conf types.NetworkConfig) {
+	networkConf = conf
+}
+
+// Validates every field and returns their values in order, or nil after answering 400.
+func validateFields(ctx *gin.Context, allowAllEmpty bool, fields ...types.UnvalidatedField) []string {
+	if !utils.ValidateAllData(fields, ctx, allowAllEmpty) {
+		return nil
+	}
+
+	var res []string
+	for _, field := range fields {
+		res = append(res, field.Getter(field.Name, ctx))
+	}
+	return res
+}
+
+func currencyChangeHandler(apply func(string, string, string, string, string, string, string, *gin.Context)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res := validateFields(ctx, false,
+			types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+			types.UnvalidatedField{"currency", utils.GetFormData, utils.ValidateCurrency, true, false},
+			types.UnvalidatedField{"amount", utils.GetFormData, utils.ValidatePositiveInt, true, false},
+			types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, true},
+			types.UnvalidatedField{"server", utils.GetFormData, utils.ValidateServerName, true, true},
+			types.UnvalidatedField{"actor", utils.GetFormData, utils.ValidateUUID, true, true},
+			types.UnvalidatedField{"idempotencyKey", utils.GetFormData, utils.ValidateIdempotencyKey, true, true},
+		)
+		if res != nil {
+			apply(res[0], res[1], res[2], res[3], res[4], res[5], res[6], ctx)
+		}
+	}
+}
+
+// Validates `uuid` and `target` and refuses a player targeting themselves.
+func friendPairHandler(getter types.UnvalidatedDataGetterFunction, apply func(string, string, *gin.Context)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res := validateFields(ctx, false,
+			types.UnvalidatedField{"uuid", getter, utils.ValidateUUID, true, false},
+			types.UnvalidatedField{"target", getter, utils.ValidateUUID, true, false},
+		)
+		if res == nil {
+			return
+		}
+		if strings.EqualFold(res[0], res[1]) {
+			utils.InputInvalidResponse(ctx, "target")
+			return
+		}
+		apply(res[0], res[1], ctx)
+	}
+}
+
 var Routes = []types.APIRoute{
 	{"", http.MethodGet, []gin.HandlerFunc{
+		responses.NotFoundResponse,
+	}},
+	{AccountPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, true},
+				types.UnvalidatedField{"name", utils.GetQueryData, utils.ValidateIgn, true, true},
+			)
+			if res != nil {
+				getAccount(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{AccountPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateIgn, true, false},
+			)
+			if res != nil {
+				createAccount(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{AccountPath, http.MethodPatch, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateIgn, true, false},
+			)
+			if res != nil {
+				renameAccount(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{AccountJoinPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateIgn, true, false},
+			)
+			if res != nil {
+				joinAccount(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{AccountPlayTimePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"minutes", utils.GetFormData, utils.ValidatePositiveInt, true, false},
+			)
+			if res != nil {
+				addPlayTime(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{CurrencyCreditPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		currencyChangeHandler(creditCurrency),
+	}},
+	{CurrencyDebitPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		currencyChangeHandler(debitCurrency),
+	}},
+	{CurrencyTransferPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"from", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"to", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"currency", utils.GetFormData, utils.ValidateCurrency, true, false},
+				types.UnvalidatedField{"amount", utils.GetFormData, utils.ValidatePositiveInt, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, true},
+				types.UnvalidatedField{"server", utils.GetFormData, utils.ValidateServerName, true, true},
+				types.UnvalidatedField{"actor", utils.GetFormData, utils.ValidateUUID, true, true},
+				types.UnvalidatedField{"idempotencyKey", utils.GetFormData, utils.ValidateIdempotencyKey, true, true},
+			)
+			if res == nil {
+				return
+			}
+			if strings.EqualFold(res[0], res[1]) {
+				utils.InputInvalidResponse(ctx, "to")
+				return
+			}
+			transferCurrency(res[0], res[1], res[2], res[3], res[4], res[5], res[6], res[7], ctx)
+		},
+	}},
+	{CurrencyHistoryPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"currency", utils.GetQueryData, utils.ValidateCurrency, true, true},
+				types.UnvalidatedField{"before", utils.GetQueryData, utils.ValidateID, true, true},
+				types.UnvalidatedField{"limit", utils.GetQueryData, utils.ValidateID, true, true},
+			)
+			if res != nil {
+				getCurrencyHistory(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{FriendsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getFriends(res[0], ctx)
+			}
+		},
+	}},
+	{FriendsPath, http.MethodDelete, []gin.HandlerFunc{
+		friendPairHandler(utils.GetQueryData, removeFriend),
+	}},
+	{FriendRequestPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		friendPairHandler(utils.GetFormData, sendFriendRequest),
+	}},
+	{FriendAcceptPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		friendPairHandler(utils.GetFormData, acceptFriendRequest),
+	}},
+	{FriendDeclinePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		friendPairHandler(utils.GetFormData, declineFriendRequest),
+	}},
+	{FriendFavouritePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"target", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"favourite", utils.GetFormData, utils.ValidateBool, true, false},
+			)
+			if res != nil {
+				setFriendFavourite(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{FriendPrivacyPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getFriendPrivacy(res[0], ctx)
+			}
+		},
+	}},
+	{FriendPrivacyPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"privacy", utils.GetFormData, utils.ValidateFriendPrivacy, true, false},
+			)
+			if res != nil {
+				setFriendPrivacy(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{PunishmentsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"active", utils.GetQueryData, utils.ValidateBool, true, true},
+			)
+			if res != nil {
+				getPunishments(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{PunishmentsPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"category", utils.GetFormData, utils.ValidatePunishmentCategory, true, false},
+				types.UnvalidatedField{"sentence", utils.GetFormData, utils.ValidatePunishmentSentence, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, false},
+				types.UnvalidatedField{"duration", utils.GetFormData, utils.ValidateDurationHours, true, false},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"severity", utils.GetFormData, utils.ValidatePositiveInt, true, true},
+				types.UnvalidatedField{"alts", utils.GetFormData, utils.ValidateBool, true, true},
+			)
+			if res != nil {
+				addPunishment(res[0], res[1], res[2], res[3], res[4], res[5], res[6], res[7], ctx)
+			}
+		},
+	}},
+	{PunishmentRemovePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, false},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				removePunishment(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{AccountIpsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getAccountIps(res[0], ctx)
+			}
+		},
+	}},
+	{IpAccountsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"ip", utils.GetQueryData, utils.ValidateIPv4, true, false},
+			)
+			if res != nil {
+				getIpAccounts(res[0], ctx)
+			}
+		},
+	}},
+	{IpBansPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"ip", utils.GetQueryData, utils.ValidateIPv4, true, false},
+				types.UnvalidatedField{"active", utils.GetQueryData, utils.ValidateBool, true, true},
+			)
+			if res != nil {
+				getIpBans(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{IpBansPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"ip", utils.GetFormData, utils.ValidateIPv4, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, false},
+				types.UnvalidatedField{"duration", utils.GetFormData, utils.ValidateDurationHours, true, false},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				addIpBan(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{IpBanRemovePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, false},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				removeIpBan(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{RanksPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getRanks(res[0], ctx)
+			}
+		},
+	}},
+	{RanksPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"rank", utils.GetFormData, utils.ValidateRank, true, false},
+				types.UnvalidatedField{"primary", utils.GetFormData, utils.ValidateBool, true, true},
+				types.UnvalidatedField{"duration", utils.GetFormData, utils.ValidateDurationHours, true, true},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				grantRank(res[0], res[1], res[2], res[3], res[4], ctx)
+			}
+		},
+	}},
+	{RankRevokePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"rank", utils.GetFormData, utils.ValidateRank, true, false},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				revokeRank(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{RankLogPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getRankLog(res[0], ctx)
+			}
+		},
+	}},
+	{RankMembersPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"rank", utils.GetQueryData, utils.ValidateRank, true, false},
+			)
+			if res != nil {
+				getRankMembers(res[0], ctx)
+			}
+		},
+	}},
+	{PreferenceDefinitionsPath, http.MethodGet, []gin.HandlerFunc{
+		getPreferenceDefinitions,
+	}},
+	{PreferenceDefinitionsPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidatePreferenceName, true, false},
+				types.UnvalidatedField{"default", utils.GetFormData, utils.ValidateBool, true, false},
+				types.UnvalidatedField{"description", utils.GetFormData, utils.ValidateReason, true, true},
+			)
+			if res != nil {
+				setPreferenceDefinition(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{PreferencesPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getPreferences(res[0], ctx)
+			}
+		},
+	}},
+	{PreferencesPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				setPreferences(res[0], ctx)
+			}
+		},
+	}},
+	{PollsPath, http.MethodGet, []gin.HandlerFunc{
+		getPolls,
+	}},
+	{PollsPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"question", utils.GetFormData, utils.ValidateText, true, false},
+				types.UnvalidatedField{"answerA", utils.GetFormData, utils.ValidateText, true, false},
+				types.UnvalidatedField{"answerB", utils.GetFormData, utils.ValidateText, true, true},
+				types.UnvalidatedField{"answerC", utils.GetFormData, utils.ValidateText, true, true},
+				types.UnvalidatedField{"answerD", utils.GetFormData, utils.ValidateText, true, true},
+				types.UnvalidatedField{"coinReward", utils.GetFormData, utils.ValidateNonNegativeInt, true, true},
+				types.UnvalidatedField{"displayType", utils.GetFormData, utils.ValidateNonNegativeInt, true, true},
+				types.UnvalidatedField{"admin", utils.GetFormData, utils.ValidateUUID, true, true},
+			)
+			if res != nil {
+				createPoll(res[0], res[1], res[2], res[3], res[4], res[5], res[6], res[7], ctx)
+			}
+		},
+	}},
+	{PollEnabledPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"enabled", utils.GetFormData, utils.ValidateBool, true, false},
+			)
+			if res != nil {
+				setPollEnabled(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{PollResultsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetQueryData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				getPollResults(res[0], ctx)
+			}
+		},
+	}},
+	{AccountNextPollPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getNextPoll(res[0], ctx)
+			}
+		},
+	}},
+	{AccountPollAnswerPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"answer", utils.GetFormData, utils.ValidatePollAnswer, true, false},
+			)
+			if res != nil {
+				answerPoll(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{ThanksPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"receiver", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"sender", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"amount", utils.GetFormData, utils.ValidatePositiveInt, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateReason, true, true},
+				types.UnvalidatedField{"ignoreCooldown", utils.GetFormData, utils.ValidateBool, true, true},
+			)
+			if res == nil {
+				return
+			}
+			if strings.EqualFold(res[0], res[1]) {
+				utils.InputInvalidResponse(ctx, "receiver")
+				return
+			}
+			addThank(res[0], res[1], res[2], res[3], res[4], ctx)
+		},
+	}},
+	{ThankAmplifierPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"amplifierId", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				checkAmplifierThank(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{ThankClaimPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				claimThank(res[0], ctx)
+			}
+		},
+	}},
+	{KitsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getKits(res[0], ctx)
+			}
+		},
+	}},
+	{KitUnlockPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"kit", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				unlockKit(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{KitActivePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"kit", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"active", utils.GetFormData, utils.ValidateBool, true, false},
+			)
+			if res != nil {
+				setKitActive(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{KitXpPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"kit", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"amount", utils.GetFormData, utils.ValidatePositiveInt, true, false},
+			)
+			if res != nil {
+				addKitXp(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{KitStatsPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"kit", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				incrementKitStats(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{StatsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"window", utils.GetQueryData, utils.ValidateStatWindow, true, true},
+			)
+			if res != nil {
+				getStats(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{StatsPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				incrementStats(res[0], ctx)
+			}
+		},
+	}},
+	{LeaderboardPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"stat", utils.GetQueryData, utils.ValidateStatName, true, false},
+				types.UnvalidatedField{"window", utils.GetQueryData, utils.ValidateStatWindow, true, true},
+				types.UnvalidatedField{"limit", utils.GetQueryData, utils.ValidateID, true, true},
+				types.UnvalidatedField{"offset", utils.GetQueryData, utils.ValidateNonNegativeInt, true, true},
+			)
+			if res != nil {
+				getStatLeaderboard(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{LeaderboardRankPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"stat", utils.GetQueryData, utils.ValidateStatName, true, false},
+				types.UnvalidatedField{"window", utils.GetQueryData, utils.ValidateStatWindow, true, true},
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getStatRank(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{LeaderboardAroundPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"stat", utils.GetQueryData, utils.ValidateStatName, true, false},
+				types.UnvalidatedField{"window", utils.GetQueryData, utils.ValidateStatWindow, true, true},
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"range", utils.GetQueryData, utils.ValidateNonNegativeInt, true, true},
+			)
+			if res != nil {
+				getStatAround(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{AccountEloPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"game", utils.GetQueryData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				getElo(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{EloMatchPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"game", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				recordEloMatch(res[0], ctx)
+			}
+		},
+	}},
+	{EloOpponentsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"game", utils.GetQueryData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"range", utils.GetQueryData, utils.ValidateNonNegativeInt, true, false},
+				types.UnvalidatedField{"limit", utils.GetQueryData, utils.ValidateID, true, true},
+			)
+			if res != nil {
+				getEloOpponents(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{WinstreaksPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				getWinstreaks(res[0], ctx)
+			}
+		},
+	}},
+	{WinstreaksPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"game", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"won", utils.GetFormData, utils.ValidateBool, true, false},
+			)
+			if res != nil {
+				reportWinstreakResult(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{ReportsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"team", utils.GetQueryData, utils.ValidateID, true, true},
+			)
+			if res != nil {
+				getOpenReports(res[0], ctx)
+			}
+		},
+	}},
+	{ReportsPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"reporter", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"suspect", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"category", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateText, true, false},
+				types.UnvalidatedField{"server", utils.GetFormData, utils.ValidateServerName, true, false},
+				types.UnvalidatedField{"weight", utils.GetFormData, utils.ValidatePositiveInt, true, true},
+			)
+			if res == nil {
+				return
+			}
+			if strings.EqualFold(res[0], res[1]) {
+				utils.InputInvalidResponse(ctx, "suspect")
+				return
+			}
+			fileReport(res[0], res[1], res[2], res[3], res[4], res[5], ctx)
+		},
+	}},
+	{ReportClaimPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"handler", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, true},
+			)
+			if res != nil {
+				claimReport(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{ReportAbortPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"handler", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				abortReport(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{ReportClosePath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"handler", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"result", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"reason", utils.GetFormData, utils.ValidateText, true, false},
+			)
+			if res != nil {
+				closeReport(res[0], res[1], res[2], res[3], ctx)
+			}
+		},
+	}},
+	{ReportTeamsPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateText, true, false},
+			)
+			if res != nil {
+				setReportTeam(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{ReportTeamMembersPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"team", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				setReportTeamMember(res[0], res[1], ctx)
+			}
+		},
+	}},
+	{ReportTeamMembersPath, http.MethodDelete, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"uuid", utils.GetQueryData, utils.ValidateUUID, true, false},
+			)
+			if res != nil {
+				removeReportTeamMember(res[0], ctx)
+			}
+		},
+	}},
+	{ReportCategoriesPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateText, true, false},
+				types.UnvalidatedField{"team", utils.GetFormData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				setReportCategory(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{ReportResultTypesPath, http.MethodPut, []gin.HandlerFunc{
+		router.LogBody,
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"name", utils.GetFormData, utils.ValidateText, true, false},
+				types.UnvalidatedField{"globalStat", utils.GetFormData, utils.ValidateBool, true, true},
+			)
+			if res != nil {
+				setReportResultType(res[0], res[1], res[2], ctx)
+			}
+		},
+	}},
+	{SnapshotMessagesPath, http.MethodPost, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"server", utils.GetFormData, utils.ValidateServerName, true, false},
+			)
+			if res != nil {
+				addSnapshotMessages(res[0], ctx)
+			}
+		},
+	}},
+	{SnapshotsPath, http.MethodGet, []gin.HandlerFunc{
+		func(ctx *gin.Context) {
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetQueryData, utils.ValidateID, true, false},
+			)
+			if res != nil {
+				getSnapshotMessages(res[0], ctx)
+			}
+		},
+	}},
+	{ReportSnapshotPath, http.MethodPost, []gin.HandlerFunc{
+		router.LogBody,
 		func(ctx *gin.Context) {
-			ctx.Status(http.StatusNotFound)
+			res := validateFields(ctx, false,
+				types.UnvalidatedField{"id", utils.GetFormData, utils.ValidateID, true, false},
+				types.UnvalidatedField{"creator", utils.GetFormData, utils.ValidateUUID, true, false},
+				types.UnvalidatedField{"minutes", utils.GetFormData, utils.ValidateID, true, true},
+				types.UnvalidatedField{"limit", utils.GetFormData, utils.ValidateID, true, true},
+			)
+			if res != nil {
+				createReportSnapshot(res[0], res[1], res[2], res[3], ctx)
+			}
 		},
 	}},
 }
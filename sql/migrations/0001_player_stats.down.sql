@@ -0,0 +1 @@
+DROP SCHEMA IF EXISTS stew_player_stats CASCADE;
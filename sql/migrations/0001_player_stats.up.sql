@@ -1,4 +1,3 @@
-DROP SCHEMA IF EXISTS stew_player_stats CASCADE;
 CREATE SCHEMA IF NOT EXISTS stew_player_stats;
 
 CREATE TABLE stew_player_stats.ipInfo
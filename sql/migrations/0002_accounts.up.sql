@@ -1,4 +1,3 @@
-DROP SCHEMA IF EXISTS stew_accounts CASCADE;
 CREATE SCHEMA stew_accounts;
 
 CREATE TABLE stew_accounts.accounts
@@ -0,0 +1,12 @@
+CREATE SCHEMA IF NOT EXISTS stew_auth;
+
+CREATE TABLE stew_auth.apiKeys
+(
+    "id"          BIGSERIAL   NOT NULL,
+    "name"        VARCHAR(64) NOT NULL UNIQUE,
+    "keyHash"     CHAR(64)    NOT NULL UNIQUE,
+    "scopes"      TEXT[]      NOT NULL DEFAULT '{}',
+    "createdTime" TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "revoked"     BOOLEAN     NOT NULL DEFAULT FALSE,
+    PRIMARY KEY ("id")
+);
@@ -0,0 +1,7 @@
+DROP FUNCTION IF EXISTS stew_accounts.addPlayTime(uuid, INT);
+DROP FUNCTION IF EXISTS stew_accounts.renameAccount(uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.joinAccount(uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.createAccount(uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.getAccountByName(VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.getAccount(uuid);
+DROP INDEX IF EXISTS stew_accounts.accounts_name_idx;
@@ -0,0 +1,68 @@
+CREATE INDEX accounts_name_idx ON stew_accounts.accounts (LOWER("name"));
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getAccount(IN inUUID uuid)
+    RETURNS SETOF stew_accounts.accounts AS
+$$
+BEGIN
+    RETURN QUERY SELECT * FROM stew_accounts.accounts WHERE accounts.uuid = inUUID;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Names are not unique over time, the most recently seen holder wins.
+CREATE OR REPLACE FUNCTION stew_accounts.getAccountByName(IN inName VARCHAR(16))
+    RETURNS SETOF stew_accounts.accounts AS
+$$
+BEGIN
+    RETURN QUERY SELECT *
+                 FROM stew_accounts.accounts
+                 WHERE LOWER(accounts.name) = LOWER(inName)
+                 ORDER BY accounts."lastLogin" DESC NULLS LAST
+                 LIMIT 1;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.createAccount(IN inUUID uuid, IN inName VARCHAR(16))
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accounts (uuid, name) VALUES (inUUID, inName);
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.joinAccount(IN inUUID uuid, IN inName VARCHAR(16))
+    RETURNS SETOF stew_accounts.accounts AS
+$$
+BEGIN
+    RETURN QUERY INSERT INTO stew_accounts.accounts (uuid, name, "lastLogin")
+        VALUES (inUUID, inName, CURRENT_TIMESTAMP)
+        ON CONFLICT (uuid) DO UPDATE SET name        = EXCLUDED.name,
+                                         "lastLogin" = EXCLUDED."lastLogin"
+        RETURNING *;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.renameAccount(IN inUUID uuid, IN inName VARCHAR(16), OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.accounts SET name = inName WHERE accounts.uuid = inUUID;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.addPlayTime(IN inUUID uuid, IN inMinutes INT, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.accounts
+    SET "totalPlayTime" = "totalPlayTime" + inMinutes
+    WHERE accounts.uuid = inUUID;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,9 @@
+DROP FUNCTION IF EXISTS stew_accounts.getCurrencyTransactions(uuid, VARCHAR, BIGINT, INT);
+DROP FUNCTION IF EXISTS stew_accounts.transferCurrency(uuid, uuid, VARCHAR, BIGINT, TEXT, VARCHAR, uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.adjustCurrency(uuid, VARCHAR, BIGINT, TEXT, VARCHAR, uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.recordCurrencyChange(uuid, VARCHAR, BIGINT, TEXT, VARCHAR, uuid, uuid, VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.lockAccount(uuid);
+DROP TABLE IF EXISTS stew_accounts.currencyTransactions;
+ALTER TABLE stew_accounts.accounts
+    DROP CONSTRAINT IF EXISTS accounts_gems_check,
+    DROP CONSTRAINT IF EXISTS accounts_coins_check;
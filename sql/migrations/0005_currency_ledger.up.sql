@@ -0,0 +1,194 @@
+ALTER TABLE stew_accounts.accounts
+    ADD CONSTRAINT accounts_gems_check CHECK ("gems" >= 0) NOT VALID,
+    ADD CONSTRAINT accounts_coins_check CHECK ("coins" >= 0) NOT VALID;
+
+CREATE TABLE stew_accounts.currencyTransactions
+(
+    "id"               BIGSERIAL   NOT NULL,
+    "playerUUID"       uuid        NOT NULL,
+    "currency"         VARCHAR(8)  NOT NULL,
+    "amount"           BIGINT      NOT NULL,
+    "balance"          BIGINT      NOT NULL,
+    "reason"           TEXT        NOT NULL DEFAULT '',
+    "server"           VARCHAR(30) NOT NULL DEFAULT '',
+    "actorUUID"        uuid                 DEFAULT NULL,
+    "counterpartyUUID" uuid                 DEFAULT NULL,
+    "idempotencyKey"   VARCHAR(64)          DEFAULT NULL,
+    "time"             TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CHECK ("currency" IN ('gems', 'coins')),
+    FOREIGN KEY ("playerUUID") REFERENCES stew_accounts.accounts ("uuid"),
+    FOREIGN KEY ("counterpartyUUID") REFERENCES stew_accounts.accounts ("uuid")
+);
+
+CREATE INDEX currencyTransactions_player_idx ON stew_accounts.currencyTransactions ("playerUUID", "id" DESC);
+CREATE UNIQUE INDEX currencyTransactions_idempotency_idx
+    ON stew_accounts.currencyTransactions ("playerUUID", "idempotencyKey")
+    WHERE "idempotencyKey" IS NOT NULL;
+
+
+-- ST002: the account does not exist.
+CREATE OR REPLACE FUNCTION stew_accounts.lockAccount(IN inUUID uuid)
+    RETURNS VOID AS
+$$
+BEGIN
+    PERFORM 1 FROM stew_accounts.accounts WHERE accounts.uuid = inUUID FOR UPDATE;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'account % does not exist', inUUID USING ERRCODE = 'ST002';
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Expects the account to be locked already. ST001: the balance would drop below zero.
+-- The balance is checked before the update, as the CHECK constraints would otherwise reject it first as a plain 23514.
+CREATE OR REPLACE FUNCTION stew_accounts.recordCurrencyChange(
+    IN inPlayerUUID uuid,
+    IN inCurrency VARCHAR(8),
+    IN inAmount BIGINT,
+    IN inReason TEXT,
+    IN inServer VARCHAR(30),
+    IN inActorUUID uuid,
+    IN inCounterpartyUUID uuid,
+    IN inIdempotencyKey VARCHAR(64),
+    OUT transactionId BIGINT,
+    OUT newBalance BIGINT)
+AS
+$$
+BEGIN
+    SELECT CASE WHEN inCurrency = 'gems' THEN a.gems ELSE a.coins END + inAmount
+    INTO newBalance
+    FROM stew_accounts.accounts a
+    WHERE a.uuid = inPlayerUUID
+        FOR UPDATE;
+
+    IF newBalance < 0 THEN
+        RAISE EXCEPTION 'insufficient % for %', inCurrency, inPlayerUUID USING ERRCODE = 'ST001';
+    END IF;
+
+    UPDATE stew_accounts.accounts
+    SET gems  = gems + CASE WHEN inCurrency = 'gems' THEN inAmount ELSE 0 END,
+        coins = coins + CASE WHEN inCurrency = 'coins' THEN inAmount ELSE 0 END
+    WHERE accounts.uuid = inPlayerUUID;
+
+    INSERT INTO stew_accounts.currencyTransactions
+    ("playerUUID", "currency", "amount", "balance", "reason", "server", "actorUUID", "counterpartyUUID", "idempotencyKey")
+    VALUES (inPlayerUUID, inCurrency, inAmount, newBalance, inReason, inServer, inActorUUID, inCounterpartyUUID,
+            inIdempotencyKey)
+    RETURNING currencyTransactions.id INTO transactionId;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- A repeated idempotency key returns the original transaction instead of applying it twice.
+-- Reusing a key for a different kind of operation trips the unique index instead.
+CREATE OR REPLACE FUNCTION stew_accounts.adjustCurrency(
+    IN inPlayerUUID uuid,
+    IN inCurrency VARCHAR(8),
+    IN inAmount BIGINT,
+    IN inReason TEXT,
+    IN inServer VARCHAR(30),
+    IN inActorUUID uuid,
+    IN inIdempotencyKey VARCHAR(64),
+    OUT transactionId BIGINT,
+    OUT newBalance BIGINT,
+    OUT replayed BOOLEAN)
+AS
+$$
+BEGIN
+    PERFORM stew_accounts.lockAccount(inPlayerUUID);
+
+    replayed := false;
+    IF inIdempotencyKey IS NOT NULL THEN
+        SELECT t.id, t.balance
+        INTO transactionId, newBalance
+        FROM stew_accounts.currencyTransactions t
+        WHERE t."playerUUID" = inPlayerUUID
+          AND t."idempotencyKey" = inIdempotencyKey
+          AND t."counterpartyUUID" IS NULL;
+        IF FOUND THEN
+            replayed := true;
+            RETURN;
+        END IF;
+    END IF;
+
+    SELECT r.transactionId, r.newBalance
+    INTO transactionId, newBalance
+    FROM stew_accounts.recordCurrencyChange(inPlayerUUID, inCurrency, inAmount, inReason, inServer, inActorUUID,
+                                            NULL, inIdempotencyKey) r;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.transferCurrency(
+    IN inFromUUID uuid,
+    IN inToUUID uuid,
+    IN inCurrency VARCHAR(8),
+    IN inAmount BIGINT,
+    IN inReason TEXT,
+    IN inServer VARCHAR(30),
+    IN inActorUUID uuid,
+    IN inIdempotencyKey VARCHAR(64),
+    OUT transactionId BIGINT,
+    OUT fromBalance BIGINT,
+    OUT toBalance BIGINT,
+    OUT replayed BOOLEAN)
+AS
+$$
+BEGIN
+    -- Always lock in the same order so two opposite transfers cannot deadlock.
+    IF inFromUUID < inToUUID THEN
+        PERFORM stew_accounts.lockAccount(inFromUUID);
+        PERFORM stew_accounts.lockAccount(inToUUID);
+    ELSE
+        PERFORM stew_accounts.lockAccount(inToUUID);
+        PERFORM stew_accounts.lockAccount(inFromUUID);
+    END IF;
+
+    replayed := false;
+    IF inIdempotencyKey IS NOT NULL THEN
+        SELECT t.id, t.balance
+        INTO transactionId, fromBalance
+        FROM stew_accounts.currencyTransactions t
+        WHERE t."playerUUID" = inFromUUID
+          AND t."idempotencyKey" = inIdempotencyKey
+          AND t."counterpartyUUID" = inToUUID;
+        IF FOUND THEN
+            SELECT t.balance
+            INTO toBalance
+            FROM stew_accounts.currencyTransactions t
+            WHERE t."playerUUID" = inToUUID
+              AND t."idempotencyKey" = inIdempotencyKey;
+            replayed := true;
+            RETURN;
+        END IF;
+    END IF;
+
+    SELECT r.transactionId, r.newBalance
+    INTO transactionId, fromBalance
+    FROM stew_accounts.recordCurrencyChange(inFromUUID, inCurrency, -inAmount, inReason, inServer, inActorUUID,
+                                            inToUUID, inIdempotencyKey) r;
+
+    SELECT r.newBalance
+    INTO toBalance
+    FROM stew_accounts.recordCurrencyChange(inToUUID, inCurrency, inAmount, inReason, inServer, inActorUUID,
+                                            inFromUUID, inIdempotencyKey) r;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Newest first. Pass the smallest id of the previous page as inBeforeId to get the next one.
+CREATE OR REPLACE FUNCTION stew_accounts.getCurrencyTransactions(
+    IN inPlayerUUID uuid, IN inCurrency VARCHAR(8), IN inBeforeId BIGINT, IN inLimit INT)
+    RETURNS SETOF stew_accounts.currencyTransactions AS
+$$
+BEGIN
+    RETURN QUERY SELECT *
+                 FROM stew_accounts.currencyTransactions t
+                 WHERE t."playerUUID" = inPlayerUUID
+                   AND (inCurrency IS NULL OR t.currency = inCurrency)
+                   AND (inBeforeId IS NULL OR t.id < inBeforeId)
+                 ORDER BY t.id DESC
+                 LIMIT inLimit;
+END
+$$ LANGUAGE plpgsql;
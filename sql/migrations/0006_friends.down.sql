@@ -0,0 +1,15 @@
+DROP FUNCTION IF EXISTS stew_accounts.setFriendPrivacy(uuid, SMALLINT);
+DROP FUNCTION IF EXISTS stew_accounts.getFriendPrivacy(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getFriends(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.setFriendFavourite(uuid, uuid, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.removeFriend(uuid, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.declineFriendRequest(uuid, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.acceptFriendRequest(uuid, uuid, INT);
+DROP FUNCTION IF EXISTS stew_accounts.sendFriendRequest(uuid, uuid, INT);
+DROP FUNCTION IF EXISTS stew_accounts.countFriendships(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.friendRequestStatus(uuid, uuid);
+DROP INDEX IF EXISTS stew_accounts.accountFriend_target_idx;
+ALTER TABLE stew_accounts.accountFriend
+    DROP COLUMN "targetFavourite";
+ALTER TABLE stew_accounts.accountFriend
+    RENAME COLUMN "sourceFavourite" TO "favourite";
@@ -0,0 +1,205 @@
+-- Each side of a friendship gets its own favourite flag.
+ALTER TABLE stew_accounts.accountFriend
+    RENAME COLUMN "favourite" TO "sourceFavourite";
+ALTER TABLE stew_accounts.accountFriend
+    ADD COLUMN "targetFavourite" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX accountFriend_target_idx ON stew_accounts.accountFriend ("uuidTarget");
+
+
+-- accountFriend.status is one of 'requestedBySource', 'requestedByTarget' or 'accepted'.
+CREATE OR REPLACE FUNCTION stew_accounts.friendRequestStatus(IN inRequester uuid, IN inOther uuid)
+    RETURNS VARCHAR(100) AS
+$$
+SELECT CASE WHEN inRequester < inOther THEN 'requestedBySource' ELSE 'requestedByTarget' END;
+$$ LANGUAGE sql IMMUTABLE;
+
+
+-- Friends plus the requests inUUID sent. Incoming requests do not count, so nobody can be pushed to the limit by others.
+CREATE OR REPLACE FUNCTION stew_accounts.countFriendships(IN inUUID uuid)
+    RETURNS BIGINT AS
+$$
+SELECT COUNT(*)
+FROM stew_accounts.accountFriend f
+WHERE (f."uuidSource" = inUUID AND f.status IN ('accepted', 'requestedBySource'))
+   OR (f."uuidTarget" = inUUID AND f.status IN ('accepted', 'requestedByTarget'));
+$$ LANGUAGE sql STABLE;
+
+
+-- ST003: the target does not accept requests. ST004: either player is at the friend limit.
+-- A request to someone who already asked us accepts theirs instead, which only needs room on our side.
+CREATE OR REPLACE FUNCTION stew_accounts.sendFriendRequest(
+    IN inUUID uuid, IN inTarget uuid, IN inLimit INT, OUT friendStatus VARCHAR(100))
+AS
+$$
+DECLARE
+    currentStatus VARCHAR(100);
+BEGIN
+    IF inUUID < inTarget THEN
+        PERFORM stew_accounts.lockAccount(inUUID);
+        PERFORM stew_accounts.lockAccount(inTarget);
+    ELSE
+        PERFORM stew_accounts.lockAccount(inTarget);
+        PERFORM stew_accounts.lockAccount(inUUID);
+    END IF;
+
+    SELECT f.status
+    INTO currentStatus
+    FROM stew_accounts.accountFriend f
+    WHERE f."uuidSource" = LEAST(inUUID, inTarget)
+      AND f."uuidTarget" = GREATEST(inUUID, inTarget);
+
+    IF FOUND THEN
+        IF currentStatus = stew_accounts.friendRequestStatus(inTarget, inUUID) THEN
+            IF stew_accounts.countFriendships(inUUID) >= inLimit THEN
+                RAISE EXCEPTION 'friend limit of % reached', inLimit USING ERRCODE = 'ST004';
+            END IF;
+            UPDATE stew_accounts.accountFriend
+            SET status = 'accepted'
+            WHERE "uuidSource" = LEAST(inUUID, inTarget)
+              AND "uuidTarget" = GREATEST(inUUID, inTarget);
+            friendStatus := 'friend';
+            RETURN;
+        END IF;
+        RAISE EXCEPTION 'friendship between % and % already exists', inUUID, inTarget
+            USING ERRCODE = 'unique_violation';
+    END IF;
+
+    IF EXISTS(SELECT 1
+              FROM stew_accounts.accountFriendData d
+              WHERE d."playerUUID" = inTarget
+                AND d.status <> 0) THEN
+        RAISE EXCEPTION '% does not accept friend requests', inTarget USING ERRCODE = 'ST003';
+    END IF;
+
+    IF stew_accounts.countFriendships(inUUID) >= inLimit OR stew_accounts.countFriendships(inTarget) >= inLimit THEN
+        RAISE EXCEPTION 'friend limit of % reached', inLimit USING ERRCODE = 'ST004';
+    END IF;
+
+    INSERT INTO stew_accounts.accountFriend ("uuidSource", "uuidTarget", "status")
+    VALUES (LEAST(inUUID, inTarget), GREATEST(inUUID, inTarget), stew_accounts.friendRequestStatus(inUUID, inTarget));
+    friendStatus := 'outgoing';
+END
+$$ LANGUAGE plpgsql;
+
+
+-- ST004: inUUID is at the friend limit.
+CREATE OR REPLACE FUNCTION stew_accounts.acceptFriendRequest(IN inUUID uuid, IN inTarget uuid, IN inLimit INT,
+                                                            OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    PERFORM stew_accounts.lockAccount(inUUID);
+    IF stew_accounts.countFriendships(inUUID) >= inLimit AND EXISTS(
+            SELECT 1
+            FROM stew_accounts.accountFriend f
+            WHERE f."uuidSource" = LEAST(inUUID, inTarget)
+              AND f."uuidTarget" = GREATEST(inUUID, inTarget)
+              AND f.status = stew_accounts.friendRequestStatus(inTarget, inUUID)) THEN
+        RAISE EXCEPTION 'friend limit of % reached', inLimit USING ERRCODE = 'ST004';
+    END IF;
+
+    UPDATE stew_accounts.accountFriend
+    SET status = 'accepted'
+    WHERE "uuidSource" = LEAST(inUUID, inTarget)
+      AND "uuidTarget" = GREATEST(inUUID, inTarget)
+      AND status = stew_accounts.friendRequestStatus(inTarget, inUUID);
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.declineFriendRequest(IN inUUID uuid, IN inTarget uuid, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    DELETE
+    FROM stew_accounts.accountFriend
+    WHERE "uuidSource" = LEAST(inUUID, inTarget)
+      AND "uuidTarget" = GREATEST(inUUID, inTarget)
+      AND status = stew_accounts.friendRequestStatus(inTarget, inUUID);
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Removes a friend or withdraws our own pending request.
+CREATE OR REPLACE FUNCTION stew_accounts.removeFriend(IN inUUID uuid, IN inTarget uuid, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    DELETE
+    FROM stew_accounts.accountFriend
+    WHERE "uuidSource" = LEAST(inUUID, inTarget)
+      AND "uuidTarget" = GREATEST(inUUID, inTarget)
+      AND status <> stew_accounts.friendRequestStatus(inTarget, inUUID);
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setFriendFavourite(
+    IN inUUID uuid, IN inTarget uuid, IN inFavourite BOOLEAN, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.accountFriend
+    SET "sourceFavourite" = CASE WHEN inUUID < inTarget THEN inFavourite ELSE "sourceFavourite" END,
+        "targetFavourite" = CASE WHEN inUUID > inTarget THEN inFavourite ELSE "targetFavourite" END
+    WHERE "uuidSource" = LEAST(inUUID, inTarget)
+      AND "uuidTarget" = GREATEST(inUUID, inTarget)
+      AND status = 'accepted';
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Friends as seen by inUUID: status is 'friend', 'outgoing' or 'incoming'.
+CREATE OR REPLACE FUNCTION stew_accounts.getFriends(IN inUUID uuid)
+    RETURNS TABLE
+            (
+                friendUUID   uuid,
+                friendName   VARCHAR(16),
+                friendStatus VARCHAR(100),
+                favourite    BOOLEAN
+            )
+AS
+$$
+BEGIN
+    RETURN QUERY SELECT a.uuid,
+                        a.name,
+                        (CASE
+                             WHEN f.status = 'accepted' THEN 'friend'
+                             WHEN f.status = stew_accounts.friendRequestStatus(inUUID, a.uuid) THEN 'outgoing'
+                             ELSE 'incoming' END)::VARCHAR(100),
+                        (CASE WHEN f."uuidSource" = inUUID THEN f."sourceFavourite" ELSE f."targetFavourite" END)
+                 FROM stew_accounts.accountFriend f
+                          INNER JOIN stew_accounts.accounts a
+                                     ON a.uuid = (CASE WHEN f."uuidSource" = inUUID THEN f."uuidTarget" ELSE f."uuidSource" END)
+                 WHERE f."uuidSource" = inUUID
+                    OR f."uuidTarget" = inUUID
+                 ORDER BY 4 DESC, a.name;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Players without a row accept requests.
+CREATE OR REPLACE FUNCTION stew_accounts.getFriendPrivacy(IN inUUID uuid, OUT privacy SMALLINT)
+AS
+$$
+BEGIN
+    SELECT d.status INTO privacy FROM stew_accounts.accountFriendData d WHERE d."playerUUID" = inUUID;
+    privacy := COALESCE(privacy, 0);
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setFriendPrivacy(IN inUUID uuid, IN inPrivacy SMALLINT)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountFriendData ("playerUUID", "status")
+    VALUES (inUUID, inPrivacy)
+    ON CONFLICT ("playerUUID") DO UPDATE SET status = inPrivacy;
+END
+$$ LANGUAGE plpgsql;
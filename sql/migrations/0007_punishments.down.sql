@@ -0,0 +1,13 @@
+DROP FUNCTION IF EXISTS stew_accounts.getActiveBan(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getPunishments(uuid, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.removePunishment(BIGINT, TEXT, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.addPunishment(uuid, TEXT, TEXT, TEXT, NUMERIC, uuid, SMALLINT);
+DROP FUNCTION IF EXISTS stew_accounts.punishmentExpiry(TIMESTAMP, NUMERIC);
+DROP INDEX IF EXISTS stew_accounts.accountPunishments_player_idx;
+ALTER TABLE stew_accounts.accountPunishments
+    DROP COLUMN "removedTime";
+UPDATE stew_accounts.accountPunishments
+SET "removerAdminUUID" = "adminUUID"
+WHERE "removerAdminUUID" IS NULL;
+ALTER TABLE stew_accounts.accountPunishments
+    ALTER COLUMN "removerAdminUUID" SET NOT NULL;
@@ -0,0 +1,113 @@
+-- Active punishments have no remover yet.
+ALTER TABLE stew_accounts.accountPunishments
+    ALTER COLUMN "removerAdminUUID" DROP NOT NULL;
+ALTER TABLE stew_accounts.accountPunishments
+    ADD COLUMN "removedTime" TIMESTAMP DEFAULT NULL;
+
+CREATE INDEX accountPunishments_player_idx ON stew_accounts.accountPunishments ("playerUUID");
+
+
+-- Durations are in hours; a negative duration never expires.
+CREATE OR REPLACE FUNCTION stew_accounts.punishmentExpiry(IN inTime TIMESTAMP, IN inDuration NUMERIC)
+    RETURNS TIMESTAMP AS
+$$
+SELECT CASE WHEN inDuration < 0 THEN NULL ELSE inTime + inDuration * INTERVAL '1 hour' END;
+$$ LANGUAGE sql IMMUTABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.addPunishment(
+    IN inPlayerUUID uuid,
+    IN inCategory TEXT,
+    IN inSentence TEXT,
+    IN inReason TEXT,
+    IN inDuration NUMERIC(16, 2),
+    IN inAdminUUID uuid,
+    IN inSeverity SMALLINT,
+    OUT punishmentId BIGINT)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountPunishments
+        ("playerUUID", "category", "sentence", "reason", "duration", "adminUUID", "severity")
+    VALUES (inPlayerUUID, inCategory, inSentence, inReason, inDuration, inAdminUUID, inSeverity)
+    RETURNING accountPunishments.id INTO punishmentId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.removePunishment(
+    IN inId BIGINT, IN inReason TEXT, IN inRemoverUUID uuid, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.accountPunishments
+    SET "removed"          = true,
+        "reasonOfRemoval"  = inReason,
+        "removerAdminUUID" = inRemoverUUID,
+        "removedTime"      = CURRENT_TIMESTAMP
+    WHERE accountPunishments.id = inId
+      AND NOT accountPunishments.removed;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getPunishments(IN inPlayerUUID uuid, IN inActiveOnly BOOLEAN)
+    RETURNS TABLE
+            (
+                id               BIGINT,
+                playerUUID       uuid,
+                category         TEXT,
+                sentence         TEXT,
+                reason           TEXT,
+                "time"           TIMESTAMP,
+                duration         NUMERIC(16, 2),
+                adminUUID        uuid,
+                severity         SMALLINT,
+                removed          BOOLEAN,
+                reasonOfRemoval  TEXT,
+                removerAdminUUID uuid,
+                removedTime      TIMESTAMP,
+                expires          TIMESTAMP,
+                active           BOOLEAN
+            )
+AS
+$$
+SELECT r.*
+FROM (SELECT p."id",
+             p."playerUUID",
+             p."category",
+             p."sentence",
+             p."reason",
+             p."time",
+             p."duration",
+             p."adminUUID",
+             p."severity",
+             p."removed",
+             p."reasonOfRemoval",
+             p."removerAdminUUID",
+             p."removedTime",
+             stew_accounts.punishmentExpiry(p."time", p."duration") AS expires,
+             NOT p."removed" AND (p."duration" < 0 OR
+                                  stew_accounts.punishmentExpiry(p."time", p."duration") > CURRENT_TIMESTAMP) AS active
+      FROM stew_accounts.accountPunishments p
+      WHERE p."playerUUID" = inPlayerUUID) r
+WHERE NOT inActiveOnly
+   OR r.active
+ORDER BY r."time" DESC, r."id" DESC;
+$$ LANGUAGE sql STABLE;
+
+
+-- The ban that keeps a player out the longest, if any.
+CREATE OR REPLACE FUNCTION stew_accounts.getActiveBan(IN inPlayerUUID uuid)
+    RETURNS SETOF stew_accounts.accountPunishments AS
+$$
+SELECT p.*
+FROM stew_accounts.accountPunishments p
+WHERE p."playerUUID" = inPlayerUUID
+  AND p."sentence" = 'Ban'
+  AND NOT p."removed"
+  AND (p."duration" < 0 OR stew_accounts.punishmentExpiry(p."time", p."duration") > CURRENT_TIMESTAMP)
+ORDER BY stew_accounts.punishmentExpiry(p."time", p."duration") DESC NULLS FIRST
+LIMIT 1;
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,98 @@
+DROP FUNCTION IF EXISTS stew_accounts.getLoginBan(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.getIpBans(VARCHAR, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.removeIpBan(BIGINT, TEXT, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.addIpBan(VARCHAR, TEXT, NUMERIC, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getPunishments(uuid, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.addPunishment(uuid, TEXT, TEXT, TEXT, NUMERIC, uuid, SMALLINT, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.punishmentActive(BOOLEAN, TIMESTAMP, NUMERIC);
+DROP TABLE IF EXISTS stew_accounts.ipBans;
+ALTER TABLE stew_accounts.accountPunishments
+    DROP COLUMN "extendToAlts";
+DROP FUNCTION IF EXISTS stew_player_stats.get_linked_players(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_player_stats.get_ip_players(VARCHAR);
+DROP FUNCTION IF EXISTS stew_player_stats.get_player_ips(uuid);
+DROP INDEX IF EXISTS stew_player_stats.ipInfo_address_idx;
+DROP INDEX IF EXISTS stew_player_stats.playerIps_ip_idx;
+DROP INDEX IF EXISTS stew_player_stats.playerIps_player_idx;
+
+
+-- Restore the 0007 versions.
+CREATE OR REPLACE FUNCTION stew_accounts.addPunishment(
+    IN inPlayerUUID uuid,
+    IN inCategory TEXT,
+    IN inSentence TEXT,
+    IN inReason TEXT,
+    IN inDuration NUMERIC(16, 2),
+    IN inAdminUUID uuid,
+    IN inSeverity SMALLINT,
+    OUT punishmentId BIGINT)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountPunishments
+        ("playerUUID", "category", "sentence", "reason", "duration", "adminUUID", "severity")
+    VALUES (inPlayerUUID, inCategory, inSentence, inReason, inDuration, inAdminUUID, inSeverity)
+    RETURNING accountPunishments.id INTO punishmentId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getPunishments(IN inPlayerUUID uuid, IN inActiveOnly BOOLEAN)
+    RETURNS TABLE
+            (
+                id               BIGINT,
+                playerUUID       uuid,
+                category         TEXT,
+                sentence         TEXT,
+                reason           TEXT,
+                "time"           TIMESTAMP,
+                duration         NUMERIC(16, 2),
+                adminUUID        uuid,
+                severity         SMALLINT,
+                removed          BOOLEAN,
+                reasonOfRemoval  TEXT,
+                removerAdminUUID uuid,
+                removedTime      TIMESTAMP,
+                expires          TIMESTAMP,
+                active           BOOLEAN
+            )
+AS
+$$
+SELECT r.*
+FROM (SELECT p."id",
+             p."playerUUID",
+             p."category",
+             p."sentence",
+             p."reason",
+             p."time",
+             p."duration",
+             p."adminUUID",
+             p."severity",
+             p."removed",
+             p."reasonOfRemoval",
+             p."removerAdminUUID",
+             p."removedTime",
+             stew_accounts.punishmentExpiry(p."time", p."duration") AS expires,
+             NOT p."removed" AND (p."duration" < 0 OR
+                                  stew_accounts.punishmentExpiry(p."time", p."duration") > CURRENT_TIMESTAMP) AS active
+      FROM stew_accounts.accountPunishments p
+      WHERE p."playerUUID" = inPlayerUUID) r
+WHERE NOT inActiveOnly
+   OR r.active
+ORDER BY r."time" DESC, r."id" DESC;
+$$ LANGUAGE sql STABLE;
+
+
+-- The ban that keeps a player out the longest, if any.
+CREATE OR REPLACE FUNCTION stew_accounts.getActiveBan(IN inPlayerUUID uuid)
+    RETURNS SETOF stew_accounts.accountPunishments AS
+$$
+SELECT p.*
+FROM stew_accounts.accountPunishments p
+WHERE p."playerUUID" = inPlayerUUID
+  AND p."sentence" = 'Ban'
+  AND NOT p."removed"
+  AND (p."duration" < 0 OR stew_accounts.punishmentExpiry(p."time", p."duration") > CURRENT_TIMESTAMP)
+ORDER BY stew_accounts.punishmentExpiry(p."time", p."duration") DESC NULLS FIRST
+LIMIT 1;
+$$ LANGUAGE sql STABLE;
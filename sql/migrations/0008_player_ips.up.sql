@@ -0,0 +1,266 @@
+CREATE INDEX IF NOT EXISTS playerIps_player_idx ON stew_player_stats.playerIps ("playerUUID");
+CREATE INDEX IF NOT EXISTS playerIps_ip_idx ON stew_player_stats.playerIps ("ipInfoId");
+CREATE INDEX IF NOT EXISTS ipInfo_address_idx ON stew_player_stats.ipInfo ("ipAddress");
+
+
+-- ipInfo may hold the same address more than once, so everything groups by address.
+CREATE OR REPLACE FUNCTION stew_player_stats.get_player_ips(
+    IN p_playerUUID uuid
+)
+    RETURNS TABLE
+            (
+                ipAddress VARCHAR(72),
+                firstSeen TIMESTAMP,
+                lastSeen  TIMESTAMP,
+                logins    BIGINT
+            )
+AS
+$$
+SELECT i."ipAddress", MIN(p."date"), MAX(p."date"), COUNT(*)
+FROM stew_player_stats.playerIps p
+         INNER JOIN stew_player_stats.ipInfo i ON i."id" = p."ipInfoId"
+WHERE p."playerUUID" = p_playerUUID
+GROUP BY i."ipAddress"
+ORDER BY 3 DESC;
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_player_stats.get_ip_players(
+    IN p_ipAddress VARCHAR(72)
+)
+    RETURNS TABLE
+            (
+                playerUUID uuid,
+                name       VARCHAR(16),
+                firstSeen  TIMESTAMP,
+                lastSeen   TIMESTAMP,
+                logins     BIGINT
+            )
+AS
+$$
+SELECT p."playerUUID", pi."name", MIN(p."date"), MAX(p."date"), COUNT(*)
+FROM stew_player_stats.playerIps p
+         INNER JOIN stew_player_stats.ipInfo i ON i."id" = p."ipInfoId"
+         INNER JOIN stew_player_stats.playerInfo pi ON pi."uuid" = p."playerUUID"
+WHERE i."ipAddress" = p_ipAddress
+GROUP BY p."playerUUID", pi."name"
+ORDER BY 4 DESC;
+$$ LANGUAGE sql STABLE;
+
+
+-- Accounts that logged in from any address inPlayerUUID used, or from inIpInfoId.
+CREATE OR REPLACE FUNCTION stew_player_stats.get_linked_players(
+    IN p_playerUUID uuid, IN p_ipInfoId BIGINT
+)
+    RETURNS SETOF uuid
+AS
+$$
+WITH addresses AS (SELECT i."ipAddress"
+                   FROM stew_player_stats.playerIps p
+                            INNER JOIN stew_player_stats.ipInfo i ON i."id" = p."ipInfoId"
+                   WHERE p."playerUUID" = p_playerUUID
+                   UNION
+                   SELECT i."ipAddress"
+                   FROM stew_player_stats.ipInfo i
+                   WHERE i."id" = p_ipInfoId)
+SELECT DISTINCT p."playerUUID"
+FROM stew_player_stats.playerIps p
+         INNER JOIN stew_player_stats.ipInfo i ON i."id" = p."ipInfoId"
+WHERE i."ipAddress" IN (SELECT a."ipAddress" FROM addresses a)
+  AND p."playerUUID" <> p_playerUUID;
+$$ LANGUAGE sql STABLE;
+
+
+CREATE TABLE stew_accounts.ipBans
+(
+    "id"               BIGSERIAL      NOT NULL,
+    "ipAddress"        VARCHAR(72)    NOT NULL,
+    "reason"           TEXT           NOT NULL,
+    "time"             TIMESTAMP      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "duration"         NUMERIC(16, 2) NOT NULL,
+    "adminUUID"        uuid           NOT NULL,
+    "removed"          BOOLEAN        NOT NULL DEFAULT false,
+    "reasonOfRemoval"  TEXT           NOT NULL DEFAULT '',
+    "removerAdminUUID" uuid                    DEFAULT NULL,
+    "removedTime"      TIMESTAMP               DEFAULT NULL,
+    PRIMARY KEY ("id"),
+    FOREIGN KEY ("adminUUID") REFERENCES stew_accounts.accounts ("uuid"),
+    FOREIGN KEY ("removerAdminUUID") REFERENCES stew_accounts.accounts ("uuid")
+);
+
+CREATE INDEX ipBans_address_idx ON stew_accounts.ipBans ("ipAddress");
+
+ALTER TABLE stew_accounts.accountPunishments
+    ADD COLUMN "extendToAlts" BOOLEAN NOT NULL DEFAULT false;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.punishmentActive(IN inRemoved BOOLEAN, IN inTime TIMESTAMP, IN inDuration NUMERIC)
+    RETURNS BOOLEAN AS
+$$
+SELECT NOT inRemoved AND (inDuration < 0 OR stew_accounts.punishmentExpiry(inTime, inDuration) > CURRENT_TIMESTAMP);
+$$ LANGUAGE sql STABLE;
+
+
+DROP FUNCTION stew_accounts.addPunishment(uuid, TEXT, TEXT, TEXT, NUMERIC, uuid, SMALLINT);
+CREATE OR REPLACE FUNCTION stew_accounts.addPunishment(
+    IN inPlayerUUID uuid,
+    IN inCategory TEXT,
+    IN inSentence TEXT,
+    IN inReason TEXT,
+    IN inDuration NUMERIC(16, 2),
+    IN inAdminUUID uuid,
+    IN inSeverity SMALLINT,
+    IN inExtendToAlts BOOLEAN,
+    OUT punishmentId BIGINT)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountPunishments
+    ("playerUUID", "category", "sentence", "reason", "duration", "adminUUID", "severity", "extendToAlts")
+    VALUES (inPlayerUUID, inCategory, inSentence, inReason, inDuration, inAdminUUID, inSeverity, inExtendToAlts)
+    RETURNING accountPunishments.id INTO punishmentId;
+END
+$$ LANGUAGE plpgsql;
+
+
+DROP FUNCTION stew_accounts.getPunishments(uuid, BOOLEAN);
+CREATE OR REPLACE FUNCTION stew_accounts.getPunishments(IN inPlayerUUID uuid, IN inActiveOnly BOOLEAN)
+    RETURNS TABLE
+            (
+                id               BIGINT,
+                playerUUID       uuid,
+                category         TEXT,
+                sentence         TEXT,
+                reason           TEXT,
+                "time"           TIMESTAMP,
+                duration         NUMERIC(16, 2),
+                adminUUID        uuid,
+                severity         SMALLINT,
+                removed          BOOLEAN,
+                reasonOfRemoval  TEXT,
+                removerAdminUUID uuid,
+                removedTime      TIMESTAMP,
+                extendToAlts     BOOLEAN,
+                expires          TIMESTAMP,
+                active           BOOLEAN
+            )
+AS
+$$
+SELECT r.*
+FROM (SELECT p."id",
+             p."playerUUID",
+             p."category",
+             p."sentence",
+             p."reason",
+             p."time",
+             p."duration",
+             p."adminUUID",
+             p."severity",
+             p."removed",
+             p."reasonOfRemoval",
+             p."removerAdminUUID",
+             p."removedTime",
+             p."extendToAlts",
+             stew_accounts.punishmentExpiry(p."time", p."duration")                AS expires,
+             stew_accounts.punishmentActive(p."removed", p."time", p."duration") AS active
+      FROM stew_accounts.accountPunishments p
+      WHERE p."playerUUID" = inPlayerUUID) r
+WHERE NOT inActiveOnly
+   OR r.active
+ORDER BY r."time" DESC, r."id" DESC;
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.addIpBan(
+    IN inIpAddress VARCHAR(72), IN inReason TEXT, IN inDuration NUMERIC(16, 2), IN inAdminUUID uuid, OUT banId BIGINT)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.ipBans ("ipAddress", "reason", "duration", "adminUUID")
+    VALUES (inIpAddress, inReason, inDuration, inAdminUUID)
+    RETURNING ipBans.id INTO banId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.removeIpBan(
+    IN inId BIGINT, IN inReason TEXT, IN inRemoverUUID uuid, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.ipBans
+    SET "removed"          = true,
+        "reasonOfRemoval"  = inReason,
+        "removerAdminUUID" = inRemoverUUID,
+        "removedTime"      = CURRENT_TIMESTAMP
+    WHERE ipBans.id = inId
+      AND NOT ipBans.removed;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getIpBans(IN inIpAddress VARCHAR(72), IN inActiveOnly BOOLEAN)
+    RETURNS TABLE
+            (
+                id               BIGINT,
+                ipAddress        VARCHAR(72),
+                reason           TEXT,
+                "time"           TIMESTAMP,
+                duration         NUMERIC(16, 2),
+                adminUUID        uuid,
+                removed          BOOLEAN,
+                reasonOfRemoval  TEXT,
+                removerAdminUUID uuid,
+                removedTime      TIMESTAMP,
+                expires          TIMESTAMP,
+                active           BOOLEAN
+            )
+AS
+$$
+SELECT r.*
+FROM (SELECT b.*,
+             stew_accounts.punishmentExpiry(b."time", b."duration")                AS expires,
+             stew_accounts.punishmentActive(b."removed", b."time", b."duration") AS active
+      FROM stew_accounts.ipBans b
+      WHERE b."ipAddress" = inIpAddress) r
+WHERE NOT inActiveOnly
+   OR r.active
+ORDER BY r."time" DESC, r."id" DESC;
+$$ LANGUAGE sql STABLE;
+
+
+DROP FUNCTION stew_accounts.getActiveBan(uuid);
+
+
+-- Why a login should be refused, if at all. kind is 'player', 'ip' or 'alt'.
+CREATE OR REPLACE FUNCTION stew_accounts.getLoginBan(IN inPlayerUUID uuid, IN inIpInfoId BIGINT)
+    RETURNS TABLE
+            (
+                kind    TEXT,
+                reason  TEXT,
+                expires TIMESTAMP
+            )
+AS
+$$
+SELECT b.kind, b.reason, b.expires
+FROM (SELECT 'player' AS kind, 1 AS rank, p."reason", stew_accounts.punishmentExpiry(p."time", p."duration") AS expires
+      FROM stew_accounts.accountPunishments p
+      WHERE p."playerUUID" = inPlayerUUID
+        AND p."sentence" = 'Ban'
+        AND stew_accounts.punishmentActive(p."removed", p."time", p."duration")
+      UNION ALL
+      SELECT 'ip', 2, i."reason", stew_accounts.punishmentExpiry(i."time", i."duration")
+      FROM stew_accounts.ipBans i
+      WHERE i."ipAddress" = (SELECT ii."ipAddress" FROM stew_player_stats.ipInfo ii WHERE ii."id" = inIpInfoId)
+        AND stew_accounts.punishmentActive(i."removed", i."time", i."duration")
+      UNION ALL
+      SELECT 'alt', 3, p."reason", stew_accounts.punishmentExpiry(p."time", p."duration")
+      FROM stew_accounts.accountPunishments p
+      WHERE p."playerUUID" IN (SELECT stew_player_stats.get_linked_players(inPlayerUUID, inIpInfoId))
+        AND p."sentence" = 'Ban'
+        AND p."extendToAlts"
+        AND stew_accounts.punishmentActive(p."removed", p."time", p."duration")) b
+ORDER BY b.rank, b.expires DESC NULLS FIRST
+LIMIT 1;
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,14 @@
+DROP FUNCTION IF EXISTS stew_accounts.getRankLog(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getRankMembers(VARCHAR);
+DROP FUNCTION IF EXISTS stew_accounts.getRanks(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.revokeRank(uuid, VARCHAR, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.grantRank(uuid, VARCHAR, BOOLEAN, NUMERIC, uuid);
+DROP FUNCTION IF EXISTS stew_accounts.rankActive(TIMESTAMP);
+DROP TABLE IF EXISTS stew_accounts.accountRankLog;
+DROP INDEX IF EXISTS stew_accounts.accountRanks_player_idx;
+DROP INDEX IF EXISTS stew_accounts.accountRanks_primary_idx;
+ALTER TABLE stew_accounts.accountRanks
+    DROP COLUMN "expires",
+    DROP COLUMN "grantedBy",
+    DROP COLUMN "grantedTime",
+    ALTER COLUMN "primaryGroup" DROP NOT NULL;
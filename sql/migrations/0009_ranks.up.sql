@@ -0,0 +1,159 @@
+UPDATE stew_accounts.accountRanks
+SET "primaryGroup" = false
+WHERE "primaryGroup" IS NULL;
+
+-- Keep one primary group per player before enforcing it.
+UPDATE stew_accounts.accountRanks r
+SET "primaryGroup" = false
+WHERE r."primaryGroup"
+  AND EXISTS(SELECT 1
+             FROM stew_accounts.accountRanks o
+             WHERE o."playerUUID" = r."playerUUID"
+               AND o."primaryGroup"
+               AND o."rankIdentifier" < r."rankIdentifier");
+
+ALTER TABLE stew_accounts.accountRanks
+    ALTER COLUMN "primaryGroup" SET NOT NULL,
+    ADD COLUMN "grantedTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN "grantedBy"   uuid               DEFAULT NULL,
+    ADD COLUMN "expires"     TIMESTAMP          DEFAULT NULL,
+    ADD FOREIGN KEY ("grantedBy") REFERENCES stew_accounts.accounts ("uuid");
+
+CREATE UNIQUE INDEX accountRanks_primary_idx ON stew_accounts.accountRanks ("playerUUID") WHERE "primaryGroup";
+CREATE INDEX accountRanks_player_idx ON stew_accounts.accountRanks ("playerUUID");
+
+CREATE TABLE stew_accounts.accountRankLog
+(
+    "id"             BIGSERIAL   NOT NULL,
+    "playerUUID"     uuid        NOT NULL,
+    "rankIdentifier" VARCHAR(10) NOT NULL,
+    "action"         VARCHAR(10) NOT NULL,
+    "primaryGroup"   BOOLEAN     NOT NULL,
+    "expires"        TIMESTAMP            DEFAULT NULL,
+    "adminUUID"      uuid        NOT NULL,
+    "time"           TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CHECK ("action" IN ('grant', 'revoke')),
+    FOREIGN KEY ("playerUUID") REFERENCES stew_accounts.accounts ("uuid"),
+    FOREIGN KEY ("adminUUID") REFERENCES stew_accounts.accounts ("uuid")
+);
+
+CREATE INDEX accountRankLog_player_idx ON stew_accounts.accountRankLog ("playerUUID", "id" DESC);
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.rankActive(IN inExpires TIMESTAMP)
+    RETURNS BOOLEAN AS
+$$
+SELECT inExpires IS NULL OR inExpires > CURRENT_TIMESTAMP;
+$$ LANGUAGE sql STABLE;
+
+
+-- Granting a primary group demotes the current one. A NULL duration never expires.
+CREATE OR REPLACE FUNCTION stew_accounts.grantRank(
+    IN inPlayerUUID uuid, IN inRank VARCHAR(10), IN inPrimary BOOLEAN, IN inDuration NUMERIC(16, 2), IN inAdminUUID uuid)
+    RETURNS VOID AS
+$$
+DECLARE
+    rankExpires TIMESTAMP := stew_accounts.punishmentExpiry(CURRENT_TIMESTAMP::TIMESTAMP, COALESCE(inDuration, -1));
+BEGIN
+    PERFORM stew_accounts.lockAccount(inPlayerUUID);
+
+    IF inPrimary THEN
+        UPDATE stew_accounts.accountRanks
+        SET "primaryGroup" = false
+        WHERE "playerUUID" = inPlayerUUID
+          AND "primaryGroup"
+          AND "rankIdentifier" <> inRank;
+    END IF;
+
+    INSERT INTO stew_accounts.accountRanks ("playerUUID", "rankIdentifier", "primaryGroup", "grantedBy", "expires")
+    VALUES (inPlayerUUID, inRank, inPrimary, inAdminUUID, rankExpires)
+    ON CONFLICT ("rankIdentifier", "playerUUID") DO UPDATE SET "primaryGroup" = inPrimary,
+                                                               "grantedTime"  = CURRENT_TIMESTAMP,
+                                                               "grantedBy"    = inAdminUUID,
+                                                               "expires"      = rankExpires;
+
+    INSERT INTO stew_accounts.accountRankLog ("playerUUID", "rankIdentifier", "action", "primaryGroup", "expires", "adminUUID")
+    VALUES (inPlayerUUID, inRank, 'grant', inPrimary, rankExpires, inAdminUUID);
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.revokeRank(
+    IN inPlayerUUID uuid, IN inRank VARCHAR(10), IN inAdminUUID uuid, OUT updated BOOLEAN)
+AS
+$$
+DECLARE
+    wasPrimary BOOLEAN;
+BEGIN
+    DELETE
+    FROM stew_accounts.accountRanks
+    WHERE "playerUUID" = inPlayerUUID
+      AND "rankIdentifier" = inRank
+    RETURNING "primaryGroup" INTO wasPrimary;
+    updated := FOUND;
+
+    IF updated THEN
+        INSERT INTO stew_accounts.accountRankLog ("playerUUID", "rankIdentifier", "action", "primaryGroup", "adminUUID")
+        VALUES (inPlayerUUID, inRank, 'revoke', wasPrimary, inAdminUUID);
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Unexpired ranks. Without an active primary group the player falls back to PLAYER.
+CREATE OR REPLACE FUNCTION stew_accounts.getRanks(IN inPlayerUUID uuid)
+    RETURNS TABLE
+            (
+                rankIdentifier VARCHAR(10),
+                primaryGroup   BOOLEAN,
+                grantedTime    TIMESTAMP,
+                grantedBy      uuid,
+                expires        TIMESTAMP
+            )
+AS
+$$
+SELECT r."rankIdentifier", r."primaryGroup", r."grantedTime", r."grantedBy", r."expires"
+FROM stew_accounts.accountRanks r
+WHERE r."playerUUID" = inPlayerUUID
+  AND stew_accounts.rankActive(r."expires")
+UNION ALL
+SELECT 'PLAYER', true, NULL, NULL, NULL
+WHERE NOT EXISTS(SELECT 1
+                 FROM stew_accounts.accountRanks r
+                 WHERE r."playerUUID" = inPlayerUUID
+                   AND r."primaryGroup"
+                   AND stew_accounts.rankActive(r."expires"))
+ORDER BY 2 DESC, 1;
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getRankMembers(IN inRank VARCHAR(10))
+    RETURNS TABLE
+            (
+                playerUUID   uuid,
+                name         VARCHAR(16),
+                primaryGroup BOOLEAN,
+                grantedTime  TIMESTAMP,
+                grantedBy    uuid,
+                expires      TIMESTAMP
+            )
+AS
+$$
+SELECT r."playerUUID", a."name", r."primaryGroup", r."grantedTime", r."grantedBy", r."expires"
+FROM stew_accounts.accountRanks r
+         INNER JOIN stew_accounts.accounts a ON a."uuid" = r."playerUUID"
+WHERE r."rankIdentifier" = inRank
+  AND stew_accounts.rankActive(r."expires")
+ORDER BY a."name";
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getRankLog(IN inPlayerUUID uuid)
+    RETURNS SETOF stew_accounts.accountRankLog AS
+$$
+SELECT *
+FROM stew_accounts.accountRankLog l
+WHERE l."playerUUID" = inPlayerUUID
+ORDER BY l."id" DESC;
+$$ LANGUAGE sql STABLE;
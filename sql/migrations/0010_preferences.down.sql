@@ -0,0 +1,7 @@
+DROP FUNCTION IF EXISTS stew_accounts.setPreferences(uuid, VARCHAR[], BOOLEAN[]);
+DROP FUNCTION IF EXISTS stew_accounts.getPreferences(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getPreferenceDefinitions();
+DROP FUNCTION IF EXISTS stew_accounts.setPreferenceDefinition(BIGINT, VARCHAR, BOOLEAN, TEXT);
+ALTER TABLE stew_accounts.preferences
+    DROP CONSTRAINT IF EXISTS preferences_id_fkey;
+DROP TABLE IF EXISTS stew_accounts.preferenceDefinitions;
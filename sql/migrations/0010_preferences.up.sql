@@ -0,0 +1,79 @@
+CREATE TABLE stew_accounts.preferenceDefinitions
+(
+    "id"           BIGINT      NOT NULL,
+    "name"         VARCHAR(64) NOT NULL,
+    "defaultValue" BOOLEAN     NOT NULL,
+    "description"  TEXT        NOT NULL DEFAULT '',
+    PRIMARY KEY ("id"),
+    UNIQUE ("name")
+);
+
+-- Rows written before the registry existed are left alone.
+ALTER TABLE stew_accounts.preferences
+    ADD CONSTRAINT preferences_id_fkey FOREIGN KEY ("id") REFERENCES stew_accounts.preferenceDefinitions ("id") NOT VALID;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setPreferenceDefinition(
+    IN inId BIGINT, IN inName VARCHAR(64), IN inDefault BOOLEAN, IN inDescription TEXT)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.preferenceDefinitions ("id", "name", "defaultValue", "description")
+    VALUES (inId, inName, inDefault, inDescription)
+    ON CONFLICT ("id") DO UPDATE SET "name"         = inName,
+                                     "defaultValue" = inDefault,
+                                     "description"  = inDescription;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getPreferenceDefinitions()
+    RETURNS SETOF stew_accounts.preferenceDefinitions AS
+$$
+SELECT *
+FROM stew_accounts.preferenceDefinitions d
+ORDER BY d."id";
+$$ LANGUAGE sql STABLE;
+
+
+-- Every registered preference, with the player's value where they set one.
+CREATE OR REPLACE FUNCTION stew_accounts.getPreferences(IN inPlayerUUID uuid)
+    RETURNS TABLE
+            (
+                id        BIGINT,
+                name      VARCHAR(64),
+                value     BOOLEAN,
+                isDefault BOOLEAN
+            )
+AS
+$$
+SELECT d."id", d."name", COALESCE(p."value", d."defaultValue"), p."value" IS NULL
+FROM stew_accounts.preferenceDefinitions d
+         LEFT JOIN stew_accounts.preferences p ON p."id" = d."id" AND p."playerUUID" = inPlayerUUID
+ORDER BY d."id";
+$$ LANGUAGE sql STABLE;
+
+
+-- ST005: a name is not registered. The offending name is reported as the column.
+CREATE OR REPLACE FUNCTION stew_accounts.setPreferences(IN inPlayerUUID uuid, IN inNames VARCHAR(64)[], IN inValues BOOLEAN[])
+    RETURNS VOID AS
+$$
+DECLARE
+    unknownName VARCHAR(64);
+BEGIN
+    SELECT n
+    INTO unknownName
+    FROM unnest(inNames) n
+    WHERE NOT EXISTS(SELECT 1 FROM stew_accounts.preferenceDefinitions d WHERE d."name" = n)
+    LIMIT 1;
+    IF FOUND THEN
+        RAISE EXCEPTION 'unknown preference %', unknownName USING ERRCODE = 'ST005', COLUMN = unknownName;
+    END IF;
+
+    INSERT INTO stew_accounts.preferences ("playerUUID", "id", "value")
+    SELECT inPlayerUUID, d."id", v.value
+    FROM unnest(inNames, inValues) v(name, value)
+             INNER JOIN stew_accounts.preferenceDefinitions d ON d."name" = v.name
+    ON CONFLICT ("playerUUID", "id") DO UPDATE SET "value" = excluded."value";
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,17 @@
+DROP FUNCTION IF EXISTS stew_accounts.getPollResults(BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.answerPoll(uuid, BIGINT, SMALLINT);
+DROP FUNCTION IF EXISTS stew_accounts.getNextPoll(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getPolls();
+DROP FUNCTION IF EXISTS stew_accounts.setPollEnabled(BIGINT, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.createPoll(TEXT, TEXT, TEXT, TEXT, TEXT, BIGINT, INT, uuid);
+DROP INDEX IF EXISTS stew_accounts.accountPolls_poll_idx;
+ALTER TABLE stew_accounts.accountPolls
+    DROP CONSTRAINT IF EXISTS accountPolls_answer_check,
+    DROP COLUMN "answeredTime",
+    DROP COLUMN "answer";
+ALTER TABLE stew_accounts.polls
+    DROP CONSTRAINT IF EXISTS polls_answers_check,
+    DROP CONSTRAINT IF EXISTS polls_reward_check,
+    DROP COLUMN "createdTime",
+    DROP COLUMN "createdBy",
+    ALTER COLUMN "enabled" DROP NOT NULL;
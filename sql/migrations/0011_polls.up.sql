@@ -0,0 +1,136 @@
+UPDATE stew_accounts.polls
+SET "enabled" = false
+WHERE "enabled" IS NULL;
+
+ALTER TABLE stew_accounts.polls
+    ALTER COLUMN "enabled" SET NOT NULL,
+    ADD COLUMN "createdBy"   uuid               DEFAULT NULL,
+    ADD COLUMN "createdTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD FOREIGN KEY ("createdBy") REFERENCES stew_accounts.accounts ("uuid"),
+    ADD CONSTRAINT polls_reward_check CHECK ("coinReward" >= 0) NOT VALID,
+    ADD CONSTRAINT polls_answers_check CHECK (("answerC" IS NULL OR "answerB" IS NOT NULL) AND
+                                              ("answerD" IS NULL OR "answerC" IS NOT NULL)) NOT VALID;
+
+-- value only said whether a player took part; answer records which option they picked (1 to 4).
+ALTER TABLE stew_accounts.accountPolls
+    ADD COLUMN "answer"       SMALLINT           DEFAULT NULL,
+    ADD COLUMN "answeredTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD CONSTRAINT accountPolls_answer_check CHECK ("answer" BETWEEN 1 AND 4);
+
+CREATE INDEX accountPolls_poll_idx ON stew_accounts.accountPolls ("pollId");
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.createPoll(
+    IN inQuestion TEXT,
+    IN inAnswerA TEXT,
+    IN inAnswerB TEXT,
+    IN inAnswerC TEXT,
+    IN inAnswerD TEXT,
+    IN inCoinReward BIGINT,
+    IN inDisplayType INT,
+    IN inCreatedBy uuid,
+    OUT pollId BIGINT)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.polls
+    ("question", "answerA", "answerB", "answerC", "answerD", "coinReward", "displayType", "createdBy")
+    VALUES (inQuestion, inAnswerA, inAnswerB, inAnswerC, inAnswerD, inCoinReward, inDisplayType, inCreatedBy)
+    RETURNING polls.id INTO pollId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setPollEnabled(IN inId BIGINT, IN inEnabled BOOLEAN, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.polls SET "enabled" = inEnabled WHERE polls.id = inId;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getPolls()
+    RETURNS SETOF stew_accounts.polls AS
+$$
+SELECT *
+FROM stew_accounts.polls p
+ORDER BY p."id" DESC;
+$$ LANGUAGE sql STABLE;
+
+
+-- The oldest enabled poll the player has not answered yet.
+CREATE OR REPLACE FUNCTION stew_accounts.getNextPoll(IN inPlayerUUID uuid)
+    RETURNS SETOF stew_accounts.polls AS
+$$
+SELECT p.*
+FROM stew_accounts.polls p
+WHERE p."enabled"
+  AND NOT EXISTS(SELECT 1
+                 FROM stew_accounts.accountPolls a
+                 WHERE a."pollId" = p."id"
+                   AND a."playerUUID" = inPlayerUUID)
+ORDER BY p."id"
+LIMIT 1;
+$$ LANGUAGE sql STABLE;
+
+
+-- Records the answer and credits the reward through the currency ledger in one go.
+-- ST002: no such poll. ST005: the poll has no such answer. ST006: the poll is disabled.
+CREATE OR REPLACE FUNCTION stew_accounts.answerPoll(
+    IN inPlayerUUID uuid, IN inPollId BIGINT, IN inAnswer SMALLINT, OUT reward BIGINT, OUT coins BIGINT)
+AS
+$$
+DECLARE
+    poll stew_accounts.polls;
+BEGIN
+    PERFORM stew_accounts.lockAccount(inPlayerUUID);
+
+    SELECT * INTO poll FROM stew_accounts.polls p WHERE p."id" = inPollId;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'poll % does not exist', inPollId USING ERRCODE = 'ST002';
+    END IF;
+    IF NOT poll."enabled" THEN
+        RAISE EXCEPTION 'poll % is disabled', inPollId USING ERRCODE = 'ST006';
+    END IF;
+    IF (inAnswer = 2 AND poll."answerB" IS NULL) OR (inAnswer = 3 AND poll."answerC" IS NULL) OR
+       (inAnswer = 4 AND poll."answerD" IS NULL) THEN
+        RAISE EXCEPTION 'poll % has no answer %', inPollId, inAnswer USING ERRCODE = 'ST005', COLUMN = 'answer';
+    END IF;
+
+    INSERT INTO stew_accounts.accountPolls ("playerUUID", "pollId", "value", "answer")
+    VALUES (inPlayerUUID, inPollId, true, inAnswer);
+
+    reward := poll."coinReward";
+    IF reward > 0 THEN
+        SELECT r.newBalance
+        INTO coins
+        FROM stew_accounts.recordCurrencyChange(inPlayerUUID, 'coins', reward, 'Poll #' || inPollId, '', NULL, NULL,
+                                                NULL) r;
+    ELSE
+        SELECT a."coins" INTO coins FROM stew_accounts.accounts a WHERE a."uuid" = inPlayerUUID;
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- One row per answer the poll offers, including answers nobody picked.
+CREATE OR REPLACE FUNCTION stew_accounts.getPollResults(IN inPollId BIGINT)
+    RETURNS TABLE
+            (
+                answer SMALLINT,
+                text   TEXT,
+                votes  BIGINT
+            )
+AS
+$$
+SELECT o.answer::SMALLINT, o.text, COUNT(a."answer")
+FROM stew_accounts.polls p
+         CROSS JOIN LATERAL (VALUES (1, p."answerA"), (2, p."answerB"), (3, p."answerC"), (4, p."answerD")) o(answer, text)
+         LEFT JOIN stew_accounts.accountPolls a ON a."pollId" = p."id" AND a."answer" = o.answer
+WHERE p."id" = inPollId
+  AND o.text IS NOT NULL
+GROUP BY o.answer, o.text
+ORDER BY o.answer;
+$$ LANGUAGE sql STABLE;
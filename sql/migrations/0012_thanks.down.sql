@@ -0,0 +1,82 @@
+DROP FUNCTION IF EXISTS stew_accounts.claimThank(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.addThank(uuid, uuid, BIGINT, TEXT, BOOLEAN, INT);
+DROP INDEX IF EXISTS stew_accounts.accountThankTransactions_sender_idx;
+DROP INDEX IF EXISTS stew_accounts.accountThankTransactions_receiver_idx;
+
+
+-- Restore the original functions. The unique constraint on amplifierId stays dropped.
+CREATE OR REPLACE FUNCTION stew_accounts.addThank(
+    IN inReceiverUUID uuid,
+    IN inSenderUUID uuid,
+    IN inThankAmount BIGINT,
+    IN inReason TEXT,
+    IN inIgnoreCooldown BOOLEAN,
+    OUT success BOOLEAN)
+AS
+$$
+DECLARE
+    insertSuccess BOOLEAN := false;
+    p_rows        BIGINT  := 0;
+BEGIN
+    INSERT INTO stew_accounts.accountThankTransactions
+    ("receiverUUID", "senderUUID", "thankAmount", "reason", "ignoreCooldown")
+    VALUES (inReceiverUUID, inSenderUUID, inThankAmount, inReason, inIgnoreCooldown);
+
+    GET DIAGNOSTICS p_rows := ROW_COUNT;
+    IF p_rows > 0 THEN
+        insertSuccess := true;
+    END IF;
+
+    success := insertSuccess;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.checkAmplifierThank(IN inPlayerUUID uuid, IN inAmplifierId BIGINT, OUT canThank BOOLEAN)
+AS
+$$
+DECLARE
+    countValue INT;
+BEGIN
+    SELECT COUNT(*)
+    INTO countValue
+    FROM stew_accounts.accountAmplifierThank
+    WHERE accountAmplifierThank."playerUUID" = inPlayerUUID
+      AND accountAmplifierThank."amplifierId" = inAmplifierId;
+
+    IF countValue > 0 THEN
+        canThank := false;
+    ELSE
+        canThank := true;
+        INSERT INTO stew_accounts.accountAmplifierThank ("playerUUID", "amplifierId")
+        VALUES (inPlayerUUID, inAmplifierId);
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.claimThank(IN inPlayerUUID uuid, OUT amountClaimed INT, OUT uniqueThank INT)
+AS
+$$
+BEGIN
+
+    SELECT SUM("thankAmount")
+    INTO amountClaimed
+    FROM stew_accounts.accountThankTransactions
+    WHERE accountThankTransactions."receiverUUID" = inPlayerUUID
+      AND claimed = false;
+
+    UPDATE stew_accounts.accountThankTransactions
+    SET claimed     = true,
+        "claimTime" = CURRENT_TIMESTAMP
+    WHERE accountThankTransactions."receiverUUID" = inPlayerUUID
+      AND accountThankTransactions.claimed = false;
+
+    SELECT COUNT(DISTINCT "senderUUID")
+    INTO uniqueThank
+    FROM stew_accounts.accountThankTransactions
+    WHERE accountThankTransactions."receiverUUID" = inPlayerUUID
+      AND claimed = false;
+
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,110 @@
+-- amplifierId was unique on its own, so only the first player could ever thank an amplifier.
+DO
+$$
+    DECLARE
+        constraintName TEXT;
+    BEGIN
+        SELECT c.conname
+        INTO constraintName
+        FROM pg_constraint c
+        WHERE c.conrelid = 'stew_accounts.accountAmplifierThank'::regclass
+          AND c.contype = 'u';
+        IF FOUND THEN
+            EXECUTE format('ALTER TABLE stew_accounts.accountAmplifierThank DROP CONSTRAINT %I', constraintName);
+        END IF;
+    END
+$$;
+
+CREATE INDEX accountThankTransactions_receiver_idx
+    ON stew_accounts.accountThankTransactions ("receiverUUID") WHERE NOT "claimed";
+CREATE INDEX accountThankTransactions_sender_idx
+    ON stew_accounts.accountThankTransactions ("senderUUID", "receiverUUID", "sentTime");
+
+
+-- A sender may thank the same receiver once per cooldown unless ignoreCooldown is set.
+-- Thanks sent with ignoreCooldown do not start a cooldown either.
+DROP FUNCTION stew_accounts.addThank(uuid, uuid, BIGINT, TEXT, BOOLEAN);
+CREATE OR REPLACE FUNCTION stew_accounts.addThank(
+    IN inReceiverUUID uuid,
+    IN inSenderUUID uuid,
+    IN inThankAmount BIGINT,
+    IN inReason TEXT,
+    IN inIgnoreCooldown BOOLEAN,
+    IN inCooldownSeconds INT,
+    OUT success BOOLEAN,
+    OUT cooldownRemaining INT)
+AS
+$$
+DECLARE
+    lastSent TIMESTAMP;
+BEGIN
+    PERFORM stew_accounts.lockAccount(inSenderUUID);
+
+    cooldownRemaining := 0;
+    IF NOT inIgnoreCooldown THEN
+        SELECT MAX(t."sentTime")
+        INTO lastSent
+        FROM stew_accounts.accountThankTransactions t
+        WHERE t."senderUUID" = inSenderUUID
+          AND t."receiverUUID" = inReceiverUUID
+          AND NOT t."ignoreCooldown";
+
+        IF lastSent IS NOT NULL AND lastSent + inCooldownSeconds * INTERVAL '1 second' > CURRENT_TIMESTAMP THEN
+            success := false;
+            cooldownRemaining := CEIL(EXTRACT(EPOCH FROM
+                                              (lastSent + inCooldownSeconds * INTERVAL '1 second' - CURRENT_TIMESTAMP)));
+            RETURN;
+        END IF;
+    END IF;
+
+    INSERT INTO stew_accounts.accountThankTransactions
+        ("receiverUUID", "senderUUID", "thankAmount", "reason", "ignoreCooldown")
+    VALUES (inReceiverUUID, inSenderUUID, inThankAmount, inReason, inIgnoreCooldown);
+    success := true;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.checkAmplifierThank(IN inPlayerUUID uuid, IN inAmplifierId BIGINT, OUT canThank BOOLEAN)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountAmplifierThank ("playerUUID", "amplifierId")
+    VALUES (inPlayerUUID, inAmplifierId)
+    ON CONFLICT DO NOTHING;
+    canThank := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Counts senders before marking rows claimed, and pays out through the currency ledger.
+DROP FUNCTION stew_accounts.claimThank(uuid);
+CREATE OR REPLACE FUNCTION stew_accounts.claimThank(
+    IN inPlayerUUID uuid, OUT amountClaimed BIGINT, OUT uniqueThank INT, OUT coins BIGINT)
+AS
+$$
+BEGIN
+    PERFORM stew_accounts.lockAccount(inPlayerUUID);
+
+    SELECT COALESCE(SUM(t."thankAmount"), 0), COUNT(DISTINCT t."senderUUID")
+    INTO amountClaimed, uniqueThank
+    FROM stew_accounts.accountThankTransactions t
+    WHERE t."receiverUUID" = inPlayerUUID
+      AND NOT t."claimed";
+
+    UPDATE stew_accounts.accountThankTransactions
+    SET "claimed"   = true,
+        "claimTime" = CURRENT_TIMESTAMP
+    WHERE accountThankTransactions."receiverUUID" = inPlayerUUID
+      AND NOT accountThankTransactions."claimed";
+
+    IF amountClaimed > 0 THEN
+        SELECT r.newBalance
+        INTO coins
+        FROM stew_accounts.recordCurrencyChange(inPlayerUUID, 'coins', amountClaimed, 'Thanks from ' || uniqueThank || ' players',
+                                                '', NULL, NULL, NULL) r;
+    ELSE
+        SELECT a."coins" INTO coins FROM stew_accounts.accounts a WHERE a."uuid" = inPlayerUUID;
+    END IF;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,14 @@
+DROP FUNCTION IF EXISTS stew_accounts.getKits(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.incrementKitStats(uuid, BIGINT, VARCHAR(255)[], BIGINT[]);
+DROP FUNCTION IF EXISTS stew_accounts.addKitXp(uuid, BIGINT, BIGINT, BIGINT[]);
+DROP FUNCTION IF EXISTS stew_accounts.setKitActive(uuid, BIGINT, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.unlockKit(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.registerStats(VARCHAR(255)[]);
+-- Stats merged by the up migration stay merged.
+DROP INDEX IF EXISTS stew_accounts.stats_name_key;
+
+-- The single-column kitId constraints are not restored, as they cannot hold once two players own the same kit.
+ALTER TABLE stew_accounts.accountKitStats
+    DROP CONSTRAINT IF EXISTS accountKitStats_kit_fkey;
+ALTER TABLE stew_accounts.kitProgression
+    DROP CONSTRAINT IF EXISTS kitProgression_kit_fkey;
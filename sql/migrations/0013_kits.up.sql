@@ -0,0 +1,214 @@
+-- kitId was unique on its own, so a kit could only ever be unlocked by one player.
+-- Progression and stats now reference the owning (player, kit) pair instead.
+DO
+$$
+    DECLARE
+        fk             RECORD;
+        constraintName TEXT;
+    BEGIN
+        FOR fk IN
+            SELECT c.conrelid::regclass AS tableName, c.conname
+            FROM pg_constraint c
+            WHERE c.conrelid IN ('stew_accounts.kitProgression'::regclass, 'stew_accounts.accountKitStats'::regclass)
+              AND c.confrelid = 'stew_accounts.accountKits'::regclass
+            LOOP
+                EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', fk.tableName, fk.conname);
+            END LOOP;
+
+        SELECT c.conname
+        INTO constraintName
+        FROM pg_constraint c
+        WHERE c.conrelid = 'stew_accounts.accountKits'::regclass
+          AND c.contype = 'u';
+        IF FOUND THEN
+            EXECUTE format('ALTER TABLE stew_accounts.accountKits DROP CONSTRAINT %I', constraintName);
+        END IF;
+    END
+$$;
+
+-- Merge stats registered more than once under the same name into the lowest id before enforcing unique names.
+-- This runs before the new kit foreign keys exist, as merged kit stat rows are checked against them.
+-- Values a player has under several of the ids are added up.
+WITH dup AS (SELECT s."id", MIN(s."id") OVER (PARTITION BY s."name") AS keep FROM stew_accounts.stats s)
+INSERT
+INTO stew_accounts.accountStatsAllTime ("playerUUID", "statId", "value")
+SELECT a."playerUUID", d.keep, SUM(a."value")
+FROM stew_accounts.accountStatsAllTime a
+         INNER JOIN dup d ON d."id" = a."statId"
+WHERE d."id" <> d.keep
+GROUP BY a."playerUUID", d.keep
+ON CONFLICT ("statId", "playerUUID") DO UPDATE SET "value" = accountStatsAllTime."value" + excluded."value";
+
+WITH dup AS (SELECT s."id", MIN(s."id") OVER (PARTITION BY s."name") AS keep FROM stew_accounts.stats s)
+INSERT
+INTO stew_accounts.accountKitStats ("playerUUID", "kitId", "statId", "value")
+SELECT k."playerUUID", k."kitId", d.keep, SUM(k."value")
+FROM stew_accounts.accountKitStats k
+         INNER JOIN dup d ON d."id" = k."statId"
+WHERE d."id" <> d.keep
+GROUP BY k."playerUUID", k."kitId", d.keep
+ON CONFLICT ("playerUUID", "kitId", "statId") DO UPDATE SET "value" = accountKitStats."value" + excluded."value";
+
+DELETE
+FROM stew_accounts.accountStatsAllTime a
+WHERE EXISTS(SELECT 1
+             FROM stew_accounts.stats s
+                      INNER JOIN stew_accounts.stats o ON o."name" = s."name" AND o."id" < s."id"
+             WHERE s."id" = a."statId");
+
+DELETE
+FROM stew_accounts.accountKitStats k
+WHERE EXISTS(SELECT 1
+             FROM stew_accounts.stats s
+                      INNER JOIN stew_accounts.stats o ON o."name" = s."name" AND o."id" < s."id"
+             WHERE s."id" = k."statId");
+
+DELETE
+FROM stew_accounts.stats s
+WHERE EXISTS(SELECT 1 FROM stew_accounts.stats o WHERE o."name" = s."name" AND o."id" < s."id");
+
+ALTER TABLE stew_accounts.kitProgression
+    ADD CONSTRAINT kitProgression_kit_fkey FOREIGN KEY ("playerUUID", "kitId")
+        REFERENCES stew_accounts.accountKits ("playerUUID", "kitId") NOT VALID;
+ALTER TABLE stew_accounts.accountKitStats
+    ADD CONSTRAINT accountKitStats_kit_fkey FOREIGN KEY ("playerUUID", "kitId")
+        REFERENCES stew_accounts.accountKits ("playerUUID", "kitId") NOT VALID;
+
+CREATE UNIQUE INDEX stats_name_key ON stew_accounts.stats ("name");
+
+
+-- Registers any names that are not known yet.
+CREATE OR REPLACE FUNCTION stew_accounts.registerStats(IN inNames VARCHAR(255)[])
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.stats ("name")
+    SELECT DISTINCT n
+    FROM unnest(inNames) n
+    ORDER BY n
+    ON CONFLICT ("name") DO NOTHING;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Unlocking a kit the player already owns changes nothing.
+CREATE OR REPLACE FUNCTION stew_accounts.unlockKit(IN inPlayerUUID uuid, IN inKitId BIGINT, OUT unlocked BOOLEAN)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountKits ("playerUUID", "kitId")
+    VALUES (inPlayerUUID, inKitId)
+    ON CONFLICT DO NOTHING;
+    unlocked := FOUND;
+
+    INSERT INTO stew_accounts.kitProgression ("playerUUID", "kitId")
+    VALUES (inPlayerUUID, inKitId)
+    ON CONFLICT DO NOTHING;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setKitActive(IN inPlayerUUID uuid, IN inKitId BIGINT, IN inActive BOOLEAN,
+                                                     OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    UPDATE stew_accounts.accountKits
+    SET "active" = inActive
+    WHERE accountKits."playerUUID" = inPlayerUUID
+      AND accountKits."kitId" = inKitId;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- inLevelXp holds the total XP needed for each level, in ascending order.
+-- Levels never go down, even if the curve is changed later.
+-- ST002: the player does not own the kit.
+CREATE OR REPLACE FUNCTION stew_accounts.addKitXp(
+    IN inPlayerUUID uuid,
+    IN inKitId BIGINT,
+    IN inAmount BIGINT,
+    IN inLevelXp BIGINT[],
+    OUT xp BIGINT,
+    OUT level BIGINT,
+    OUT previousLevel BIGINT)
+AS
+$$
+BEGIN
+    SELECT p."xp" + inAmount, p."level", p."level"
+    INTO xp, level, previousLevel
+    FROM stew_accounts.kitProgression p
+    WHERE p."playerUUID" = inPlayerUUID
+      AND p."kitId" = inKitId
+        FOR UPDATE;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'kit % not owned by %', inKitId, inPlayerUUID USING ERRCODE = 'ST002';
+    END IF;
+
+    level := GREATEST(level, (SELECT COUNT(*) FROM unnest(inLevelXp) t WHERE t <= xp));
+
+    UPDATE stew_accounts.kitProgression
+    SET "xp"    = addKitXp.xp,
+        "level" = addKitXp.level
+    WHERE kitProgression."playerUUID" = inPlayerUUID
+      AND kitProgression."kitId" = inKitId;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Unknown stat names are registered on the fly.
+-- ST002: the player does not own the kit.
+CREATE OR REPLACE FUNCTION stew_accounts.incrementKitStats(IN inPlayerUUID uuid, IN inKitId BIGINT,
+                                                          IN inNames VARCHAR(255)[], IN inAmounts BIGINT[])
+    RETURNS VOID AS
+$$
+BEGIN
+    PERFORM 1
+    FROM stew_accounts.accountKits k
+    WHERE k."playerUUID" = inPlayerUUID
+      AND k."kitId" = inKitId;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'kit % not owned by %', inKitId, inPlayerUUID USING ERRCODE = 'ST002';
+    END IF;
+
+    PERFORM stew_accounts.registerStats(inNames);
+
+    INSERT INTO stew_accounts.accountKitStats ("playerUUID", "kitId", "statId", "value")
+    SELECT inPlayerUUID, inKitId, s."id", SUM(v.amount)
+    FROM unnest(inNames, inAmounts) v(name, amount)
+             INNER JOIN stew_accounts.stats s ON s."name" = v.name
+    GROUP BY s."id"
+    ON CONFLICT ("playerUUID", "kitId", "statId") DO UPDATE SET "value" = accountKitStats."value" + excluded."value";
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Every kit the player owns with its progression and stats.
+CREATE OR REPLACE FUNCTION stew_accounts.getKits(IN inPlayerUUID uuid)
+    RETURNS TABLE
+            (
+                kitId        BIGINT,
+                active       BOOLEAN,
+                xp           BIGINT,
+                level        BIGINT,
+                upgradeLevel BIGINT,
+                stats        JSONB
+            )
+AS
+$$
+SELECT k."kitId",
+       k."active",
+       COALESCE(p."xp", 0),
+       COALESCE(p."level", 0),
+       COALESCE(p."upgrade_level", 0),
+       COALESCE((SELECT jsonb_object_agg(s."name", ks."value")
+                 FROM stew_accounts.accountKitStats ks
+                          INNER JOIN stew_accounts.stats s ON s."id" = ks."statId"
+                 WHERE ks."playerUUID" = k."playerUUID"
+                   AND ks."kitId" = k."kitId"), '{}'::JSONB)
+FROM stew_accounts.accountKits k
+         LEFT JOIN stew_accounts.kitProgression p ON p."playerUUID" = k."playerUUID" AND p."kitId" = k."kitId"
+WHERE k."playerUUID" = inPlayerUUID
+ORDER BY k."kitId";
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,10 @@
+DROP FUNCTION IF EXISTS stew_accounts.getStatAround(VARCHAR(255), TEXT, uuid, INT);
+DROP FUNCTION IF EXISTS stew_accounts.getStatLeaderboard(VARCHAR(255), TEXT, INT, INT);
+DROP FUNCTION IF EXISTS stew_accounts.statRanking(VARCHAR(255), TEXT);
+DROP FUNCTION IF EXISTS stew_accounts.getStats(uuid, TEXT);
+DROP FUNCTION IF EXISTS stew_accounts.statValues(VARCHAR(255), TEXT);
+DROP FUNCTION IF EXISTS stew_accounts.incrementStats(uuid, VARCHAR(255)[], BIGINT[]);
+DROP FUNCTION IF EXISTS stew_accounts.statWindowStart(TEXT);
+DROP INDEX IF EXISTS stew_accounts.accountStatsAllTime_value_idx;
+DROP INDEX IF EXISTS stew_accounts.accountStatsAllTime_player_idx;
+DROP TABLE IF EXISTS stew_accounts.accountStatsWindowed;
@@ -0,0 +1,165 @@
+-- One row per player, stat and period. windowStart is the first day of the ISO week or calendar month.
+CREATE TABLE stew_accounts.accountStatsWindowed
+(
+    "playerUUID"  uuid        NOT NULL,
+    "statId"      BIGINT      NOT NULL,
+    "window"      VARCHAR(16) NOT NULL,
+    "windowStart" DATE        NOT NULL,
+    "value"       BIGINT      NOT NULL,
+    PRIMARY KEY ("statId", "window", "windowStart", "playerUUID"),
+    FOREIGN KEY ("playerUUID") REFERENCES stew_accounts.accounts ("uuid"),
+    FOREIGN KEY ("statId") REFERENCES stew_accounts.stats ("id"),
+    CHECK ("window" IN ('weekly', 'monthly'))
+);
+
+CREATE INDEX accountStatsAllTime_player_idx ON stew_accounts.accountStatsAllTime ("playerUUID");
+CREATE INDEX accountStatsAllTime_value_idx ON stew_accounts.accountStatsAllTime ("statId", "value" DESC);
+CREATE INDEX accountStatsWindowed_value_idx
+    ON stew_accounts.accountStatsWindowed ("statId", "window", "windowStart", "value" DESC);
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.statWindowStart(IN inWindow TEXT)
+    RETURNS DATE AS
+$$
+SELECT CASE inWindow
+           WHEN 'weekly' THEN date_trunc('week', LOCALTIMESTAMP)::DATE
+           WHEN 'monthly' THEN date_trunc('month', LOCALTIMESTAMP)::DATE
+           END;
+$$ LANGUAGE sql STABLE;
+
+
+-- Unknown stat names are registered on the fly. The current weekly and monthly periods are updated along with the all-time value.
+CREATE OR REPLACE FUNCTION stew_accounts.incrementStats(IN inPlayerUUID uuid, IN inNames VARCHAR(255)[], IN inAmounts BIGINT[])
+    RETURNS VOID AS
+$$
+BEGIN
+    PERFORM stew_accounts.registerStats(inNames);
+
+    INSERT INTO stew_accounts.accountStatsAllTime ("playerUUID", "statId", "value")
+    SELECT inPlayerUUID, s."id", SUM(v.amount)
+    FROM unnest(inNames, inAmounts) v(name, amount)
+             INNER JOIN stew_accounts.stats s ON s."name" = v.name
+    GROUP BY s."id"
+    ORDER BY s."id"
+    ON CONFLICT ("statId", "playerUUID") DO UPDATE SET "value" = accountStatsAllTime."value" + excluded."value";
+
+    INSERT INTO stew_accounts.accountStatsWindowed ("playerUUID", "statId", "window", "windowStart", "value")
+    SELECT inPlayerUUID, s."id", w.name, stew_accounts.statWindowStart(w.name), SUM(v.amount)
+    FROM unnest(inNames, inAmounts) v(name, amount)
+             INNER JOIN stew_accounts.stats s ON s."name" = v.name
+             CROSS JOIN (VALUES ('weekly'), ('monthly')) w(name)
+    GROUP BY s."id", w.name
+    ORDER BY s."id", w.name
+    ON CONFLICT ("statId", "window", "windowStart", "playerUUID") DO UPDATE SET "value" = accountStatsWindowed."value" + excluded."value";
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Every player's value of a stat in the current period of a window.
+CREATE OR REPLACE FUNCTION stew_accounts.statValues(IN inName VARCHAR(255), IN inWindow TEXT)
+    RETURNS TABLE
+            (
+                playerUUID uuid,
+                value      BIGINT
+            )
+AS
+$$
+SELECT a."playerUUID", a."value"
+FROM stew_accounts.accountStatsAllTime a
+         INNER JOIN stew_accounts.stats s ON s."id" = a."statId"
+WHERE s."name" = inName
+  AND inWindow = 'all'
+UNION ALL
+SELECT w."playerUUID", w."value"
+FROM stew_accounts.accountStatsWindowed w
+         INNER JOIN stew_accounts.stats s ON s."id" = w."statId"
+WHERE s."name" = inName
+  AND w."window" = inWindow
+  AND w."windowStart" = stew_accounts.statWindowStart(inWindow);
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getStats(IN inPlayerUUID uuid, IN inWindow TEXT)
+    RETURNS TABLE
+            (
+                name  VARCHAR(255),
+                value BIGINT
+            )
+AS
+$$
+SELECT s."name", a."value"
+FROM stew_accounts.accountStatsAllTime a
+         INNER JOIN stew_accounts.stats s ON s."id" = a."statId"
+WHERE a."playerUUID" = inPlayerUUID
+  AND inWindow = 'all'
+UNION ALL
+SELECT s."name", w."value"
+FROM stew_accounts.accountStatsWindowed w
+         INNER JOIN stew_accounts.stats s ON s."id" = w."statId"
+WHERE w."playerUUID" = inPlayerUUID
+  AND w."window" = inWindow
+  AND w."windowStart" = stew_accounts.statWindowStart(inWindow)
+ORDER BY 1;
+$$ LANGUAGE sql STABLE;
+
+
+-- Players with equal values share a rank. position breaks ties so neighbours can be found.
+CREATE OR REPLACE FUNCTION stew_accounts.statRanking(IN inName VARCHAR(255), IN inWindow TEXT)
+    RETURNS TABLE
+            (
+                position   BIGINT,
+                rank       BIGINT,
+                playerUUID uuid,
+                name       VARCHAR(16),
+                value      BIGINT
+            )
+AS
+$$
+SELECT ROW_NUMBER() OVER (ORDER BY v.value DESC, a."name", v.playerUUID),
+       RANK() OVER (ORDER BY v.value DESC),
+       v.playerUUID,
+       a."name",
+       v.value
+FROM stew_accounts.statValues(inName, inWindow) v
+         INNER JOIN stew_accounts.accounts a ON a."uuid" = v.playerUUID;
+$$ LANGUAGE sql STABLE;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getStatLeaderboard(IN inName VARCHAR(255), IN inWindow TEXT,
+                                                           IN inLimit INT, IN inOffset INT)
+    RETURNS TABLE
+            (
+                rank       BIGINT,
+                playerUUID uuid,
+                name       VARCHAR(16),
+                value      BIGINT
+            )
+AS
+$$
+SELECT r.rank, r.playerUUID, r.name, r.value
+FROM stew_accounts.statRanking(inName, inWindow) r
+ORDER BY r.position
+LIMIT inLimit OFFSET inOffset;
+$$ LANGUAGE sql STABLE;
+
+
+-- The player and up to inRange players on either side of them. Empty when the player has no value.
+CREATE OR REPLACE FUNCTION stew_accounts.getStatAround(IN inName VARCHAR(255), IN inWindow TEXT,
+                                                      IN inPlayerUUID uuid, IN inRange INT)
+    RETURNS TABLE
+            (
+                rank       BIGINT,
+                playerUUID uuid,
+                name       VARCHAR(16),
+                value      BIGINT
+            )
+AS
+$$
+WITH ranking AS (SELECT * FROM stew_accounts.statRanking(inName, inWindow))
+SELECT r.rank, r.playerUUID, r.name, r.value
+FROM ranking r,
+     ranking p
+WHERE p.playerUUID = inPlayerUUID
+  AND r.position BETWEEN p.position - inRange AND p.position + inRange
+ORDER BY r.position;
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS stew_accounts.getEloOpponents(BIGINT, uuid, BIGINT, BIGINT, INT);
+DROP FUNCTION IF EXISTS stew_accounts.recordEloMatch(BIGINT, uuid[], INT[], INT[], INT, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.getElo(uuid, BIGINT, BIGINT);
+DROP INDEX IF EXISTS stew_accounts.eloRating_game_idx;
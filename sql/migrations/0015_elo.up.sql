@@ -0,0 +1,102 @@
+CREATE INDEX eloRating_game_idx ON stew_accounts.eloRating ("gameId", "elo");
+
+
+-- Unrated players start at inInitialElo.
+CREATE OR REPLACE FUNCTION stew_accounts.getElo(IN inPlayerUUID uuid, IN inGameId BIGINT, IN inInitialElo BIGINT,
+                                               OUT elo BIGINT, OUT rated BOOLEAN)
+AS
+$$
+SELECT COALESCE(MAX(e."elo"), inInitialElo), COUNT(*) > 0
+FROM stew_accounts.eloRating e
+WHERE e."playerUUID" = inPlayerUUID
+  AND e."gameId" = inGameId;
+$$ LANGUAGE sql STABLE;
+
+
+-- Every team is compared with every other team: a better placement scores 1, a tie 0.5 and a worse one 0.
+-- A team's rating is the average of its members, and every member moves by inKFactor times the team's
+-- average surprise across those pairings.
+-- inPlayers, inTeams and inPlacements are parallel; every member of a team carries the team's placement.
+CREATE OR REPLACE FUNCTION stew_accounts.recordEloMatch(
+    IN inGameId BIGINT,
+    IN inPlayers uuid[],
+    IN inTeams INT[],
+    IN inPlacements INT[],
+    IN inKFactor INT,
+    IN inInitialElo BIGINT)
+    RETURNS TABLE
+            (
+                playerUUID  uuid,
+                team        INT,
+                placement   INT,
+                previousElo BIGINT,
+                newElo      BIGINT
+            )
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.eloRating ("playerUUID", "gameId", "elo")
+    SELECT DISTINCT u, inGameId, inInitialElo
+    FROM unnest(inPlayers) u
+    ORDER BY u
+    ON CONFLICT DO NOTHING;
+
+    PERFORM 1
+    FROM stew_accounts.eloRating e
+    WHERE e."gameId" = inGameId
+      AND e."playerUUID" = ANY (inPlayers)
+    ORDER BY e."playerUUID"
+        FOR UPDATE;
+
+    RETURN QUERY
+        WITH participants AS (SELECT m.uuid, m.teamId, m.place, e."elo" AS rating
+                              FROM unnest(inPlayers, inTeams, inPlacements) m(uuid, teamId, place)
+                                       INNER JOIN stew_accounts.eloRating e
+                                                  ON e."playerUUID" = m.uuid AND e."gameId" = inGameId),
+             teams AS (SELECT p.teamId, MIN(p.place) AS place, AVG(p.rating) AS rating
+                       FROM participants p
+                       GROUP BY p.teamId),
+             deltas AS (SELECT a.teamId,
+                               inKFactor * AVG(CASE
+                                                   WHEN a.place < b.place THEN 1
+                                                   WHEN a.place = b.place THEN 0.5
+                                                   ELSE 0 END
+                                   - 1 / (1 + power(10::NUMERIC, (b.rating - a.rating) / 400))) AS delta
+                        FROM teams a
+                                 INNER JOIN teams b ON a.teamId <> b.teamId
+                        GROUP BY a.teamId),
+             updated AS (UPDATE stew_accounts.eloRating e
+                 SET "elo" = e."elo" + ROUND(d.delta)::BIGINT
+                 FROM participants p
+                          INNER JOIN deltas d ON d.teamId = p.teamId
+                 WHERE e."playerUUID" = p.uuid
+                   AND e."gameId" = inGameId
+                 RETURNING p.uuid, p.teamId, p.place, p.rating, e."elo" AS updatedElo)
+        SELECT u.uuid, u.teamId, u.place, u.rating, u.updatedElo
+        FROM updated u
+        ORDER BY u.place, u.teamId, u.uuid;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Rated players within inRange of the player's rating, closest first.
+CREATE OR REPLACE FUNCTION stew_accounts.getEloOpponents(IN inGameId BIGINT, IN inPlayerUUID uuid, IN inInitialElo BIGINT,
+                                                        IN inRange BIGINT, IN inLimit INT)
+    RETURNS TABLE
+            (
+                playerUUID uuid,
+                name       VARCHAR(16),
+                elo        BIGINT
+            )
+AS
+$$
+SELECT e."playerUUID", a."name", e."elo"
+FROM stew_accounts.getElo(inPlayerUUID, inGameId, inInitialElo) r,
+     stew_accounts.eloRating e
+         INNER JOIN stew_accounts.accounts a ON a."uuid" = e."playerUUID"
+WHERE e."gameId" = inGameId
+  AND e."elo" BETWEEN r.elo - inRange AND r.elo + inRange
+  AND e."playerUUID" <> inPlayerUUID
+ORDER BY abs(e."elo" - r.elo), e."playerUUID"
+LIMIT inLimit;
+$$ LANGUAGE sql STABLE;
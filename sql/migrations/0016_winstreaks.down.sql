@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS stew_accounts.getWinstreaks(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.reportWinstreakResult(uuid, BIGINT, BOOLEAN);
+ALTER TABLE stew_accounts.accountWinstreak
+    DROP COLUMN "best";
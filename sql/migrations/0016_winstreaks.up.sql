@@ -0,0 +1,56 @@
+ALTER TABLE stew_accounts.accountWinstreak
+    ADD COLUMN "best" BIGINT NOT NULL DEFAULT 0;
+UPDATE stew_accounts.accountWinstreak
+SET "best" = "value";
+
+
+-- A win extends the current streak and a loss resets it. The best streak never goes down.
+CREATE OR REPLACE FUNCTION stew_accounts.reportWinstreakResult(
+    IN inPlayerUUID uuid,
+    IN inGameId BIGINT,
+    IN inWon BOOLEAN,
+    OUT streak BIGINT,
+    OUT previousStreak BIGINT,
+    OUT best BIGINT,
+    OUT newBest BOOLEAN)
+AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.accountWinstreak ("playerUUID", "gameId", "value", "best")
+    VALUES (inPlayerUUID, inGameId, 0, 0)
+    ON CONFLICT DO NOTHING;
+
+    SELECT w."value", w."best"
+    INTO previousStreak, best
+    FROM stew_accounts.accountWinstreak w
+    WHERE w."playerUUID" = inPlayerUUID
+      AND w."gameId" = inGameId
+        FOR UPDATE;
+
+    streak := CASE WHEN inWon THEN previousStreak + 1 ELSE 0 END;
+    newBest := streak > best;
+    best := GREATEST(best, streak);
+
+    UPDATE stew_accounts.accountWinstreak
+    SET "value" = streak,
+        "best"  = reportWinstreakResult.best
+    WHERE accountWinstreak."playerUUID" = inPlayerUUID
+      AND accountWinstreak."gameId" = inGameId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.getWinstreaks(IN inPlayerUUID uuid)
+    RETURNS TABLE
+            (
+                gameId BIGINT,
+                streak BIGINT,
+                best   BIGINT
+            )
+AS
+$$
+SELECT w."gameId", w."value", w."best"
+FROM stew_accounts.accountWinstreak w
+WHERE w."playerUUID" = inPlayerUUID
+ORDER BY w."gameId";
+$$ LANGUAGE sql STABLE;
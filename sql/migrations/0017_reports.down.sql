@@ -0,0 +1,28 @@
+DROP FUNCTION IF EXISTS stew_accounts.closeReport(uuid, BIGINT, SMALLINT, TEXT);
+DROP FUNCTION IF EXISTS stew_accounts.abortReport(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.lockHandledReport(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.claimReport(uuid, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.reportHandlerTeam(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.getOpenReports(SMALLINT, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.fileReport(uuid, uuid, SMALLINT, TEXT, VARCHAR, BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.removeReportTeamMember(uuid);
+DROP FUNCTION IF EXISTS stew_accounts.setReportTeamMember(uuid, SMALLINT);
+DROP FUNCTION IF EXISTS stew_accounts.setReportResultType(SMALLINT, VARCHAR, BOOLEAN);
+DROP FUNCTION IF EXISTS stew_accounts.setReportCategory(SMALLINT, VARCHAR, SMALLINT);
+DROP FUNCTION IF EXISTS stew_accounts.setReportTeam(SMALLINT, VARCHAR);
+
+DROP INDEX IF EXISTS stew_accounts.reportResults_report_key;
+ALTER TABLE stew_accounts.reportResults
+    DROP COLUMN "handlerUUID",
+    DROP COLUMN "resultTypeId";
+ALTER TABLE stew_accounts.reportHandlers
+    DROP COLUMN "claimedTime";
+DROP INDEX IF EXISTS stew_accounts.reports_team_idx;
+DROP INDEX IF EXISTS stew_accounts.reports_suspect_idx;
+ALTER TABLE stew_accounts.reports
+    DROP COLUMN "createdTime";
+ALTER TABLE stew_accounts.reportCategoryTypes
+    DROP COLUMN "teamId";
+
+-- reportReasons keeps its (reportId, reporterUUID) key and reports.snapshotId stays nullable,
+-- as merged reports and reports without snapshots cannot satisfy the original constraints.
@@ -0,0 +1,345 @@
+-- New reports are routed to their category's team.
+ALTER TABLE stew_accounts.reportCategoryTypes
+    ADD COLUMN "teamId" SMALLINT,
+    ADD CONSTRAINT reportCategoryTypes_teamId_fkey FOREIGN KEY ("teamId") REFERENCES stew_accounts.reportTeams ("id");
+
+-- Snapshots are attached after a report is filed, if at all.
+ALTER TABLE stew_accounts.reports
+    ALTER COLUMN "snapshotId" DROP NOT NULL,
+    ALTER COLUMN "snapshotId" DROP DEFAULT,
+    ADD COLUMN "createdTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+CREATE INDEX reports_suspect_idx ON stew_accounts.reports ("suspectUUID", "categoryId");
+CREATE INDEX reports_team_idx ON stew_accounts.reports ("assignedTeam");
+
+-- reportId was the primary key on its own, so a report could never have more than one reporter.
+ALTER TABLE stew_accounts.reportReasons
+    DROP CONSTRAINT reportReasons_pkey,
+    ALTER COLUMN "reportId" DROP DEFAULT,
+    ADD PRIMARY KEY ("reportId", "reporterUUID");
+
+-- One handler per report. An aborted claim is taken over by the next handler.
+ALTER TABLE stew_accounts.reportHandlers
+    ADD COLUMN "claimedTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- A report is open until it has a result.
+ALTER TABLE stew_accounts.reportResults
+    ADD COLUMN "resultTypeId" SMALLINT,
+    ADD COLUMN "handlerUUID"  uuid,
+    ADD CONSTRAINT reportResults_resultTypeId_fkey FOREIGN KEY ("resultTypeId") REFERENCES stew_accounts.reportResultTypes ("id"),
+    ADD CONSTRAINT reportResults_handlerUUID_fkey FOREIGN KEY ("handlerUUID") REFERENCES stew_accounts.accounts ("uuid");
+CREATE UNIQUE INDEX reportResults_report_key ON stew_accounts.reportResults ("reportId");
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setReportTeam(IN inId SMALLINT, IN inName VARCHAR(50))
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.reportTeams ("id", "name")
+    VALUES (inId, inName)
+    ON CONFLICT ("id") DO UPDATE SET "name" = inName;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setReportCategory(IN inId SMALLINT, IN inName VARCHAR(16), IN inTeamId SMALLINT)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.reportCategoryTypes ("id", "name", "teamId")
+    VALUES (inId, inName, inTeamId)
+    ON CONFLICT ("id") DO UPDATE SET "name"   = inName,
+                                     "teamId" = inTeamId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.setReportResultType(IN inId SMALLINT, IN inName VARCHAR(16), IN inGlobalStat BOOLEAN)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.reportResultTypes ("id", "name", "globalStat")
+    VALUES (inId, inName, inGlobalStat)
+    ON CONFLICT ("id") DO UPDATE SET "name"       = inName,
+                                     "globalStat" = inGlobalStat;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- A player belongs to at most one team.
+CREATE OR REPLACE FUNCTION stew_accounts.setReportTeamMember(IN inPlayerUUID uuid, IN inTeamId SMALLINT)
+    RETURNS VOID AS
+$$
+BEGIN
+    INSERT INTO stew_accounts.reportTeamMemberships ("playerUUID", "teamId")
+    VALUES (inPlayerUUID, inTeamId)
+    ON CONFLICT ("playerUUID") DO UPDATE SET "teamId" = inTeamId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.removeReportTeamMember(IN inPlayerUUID uuid, OUT updated BOOLEAN)
+AS
+$$
+BEGIN
+    DELETE
+    FROM stew_accounts.reportTeamMemberships
+    WHERE reportTeamMemberships."playerUUID" = inPlayerUUID;
+    updated := FOUND;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Joins the open report for the same suspect and category when there is one.
+-- A reporter counts once per report; reporting again only updates their reason.
+-- ST002: unknown suspect or category. ST005: the category has no team.
+CREATE OR REPLACE FUNCTION stew_accounts.fileReport(
+    IN inReporterUUID uuid,
+    IN inSuspectUUID uuid,
+    IN inCategoryId SMALLINT,
+    IN inReason TEXT,
+    IN inServer VARCHAR(30),
+    IN inWeight BIGINT,
+    OUT reportId BIGINT,
+    OUT merged BOOLEAN,
+    OUT weight BIGINT)
+AS
+$$
+DECLARE
+    teamId SMALLINT;
+BEGIN
+    PERFORM stew_accounts.lockAccount(inSuspectUUID);
+
+    SELECT c."teamId"
+    INTO teamId
+    FROM stew_accounts.reportCategoryTypes c
+    WHERE c."id" = inCategoryId;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'unknown report category %', inCategoryId USING ERRCODE = 'ST002';
+    END IF;
+    IF teamId IS NULL THEN
+        RAISE EXCEPTION 'report category % has no team', inCategoryId USING ERRCODE = 'ST005', COLUMN = 'categoryId';
+    END IF;
+
+    SELECT r."id"
+    INTO reportId
+    FROM stew_accounts.reports r
+    WHERE r."suspectUUID" = inSuspectUUID
+      AND r."categoryId" = inCategoryId
+      AND NOT EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = r."id")
+    ORDER BY r."id" DESC
+    LIMIT 1;
+    merged := FOUND;
+
+    IF NOT merged THEN
+        INSERT INTO stew_accounts.reports ("suspectUUID", "categoryId", "assignedTeam")
+        VALUES (inSuspectUUID, inCategoryId, teamId)
+        RETURNING reports."id" INTO reportId;
+    END IF;
+
+    INSERT INTO stew_accounts.reportReasons ("reportId", "reporterUUID", "reason", "server", "weight")
+    VALUES (reportId, inReporterUUID, inReason, inServer, inWeight)
+    ON CONFLICT ("reportId", "reporterUUID") DO UPDATE SET "reason" = excluded."reason",
+                                                           "server" = excluded."server",
+                                                           "time"   = CURRENT_TIMESTAMP;
+
+    SELECT SUM(rr."weight")
+    INTO weight
+    FROM stew_accounts.reportReasons rr
+    WHERE rr."reportId" = fileReport.reportId;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Open reports, heaviest first. Both filters are optional.
+-- handlerUUID is null while nobody is working on the report.
+CREATE OR REPLACE FUNCTION stew_accounts.getOpenReports(IN inTeamId SMALLINT, IN inReportId BIGINT)
+    RETURNS TABLE
+            (
+                id          BIGINT,
+                suspectUUID uuid,
+                suspectName VARCHAR(16),
+                categoryId  SMALLINT,
+                teamId      SMALLINT,
+                weight      BIGINT,
+                reporters   BIGINT,
+                snapshotId  BIGINT,
+                handlerUUID uuid,
+                createdTime TIMESTAMP
+            )
+AS
+$$
+SELECT r."id",
+       r."suspectUUID",
+       a."name",
+       r."categoryId",
+       r."assignedTeam"::SMALLINT,
+       COALESCE(w.weight, 0),
+       COALESCE(w.reporters, 0),
+       r."snapshotId",
+       CASE WHEN h."aborted" THEN NULL ELSE h."handlerUUID" END,
+       r."createdTime"
+FROM stew_accounts.reports r
+         INNER JOIN stew_accounts.accounts a ON a."uuid" = r."suspectUUID"
+         LEFT JOIN (SELECT rr."reportId", SUM(rr."weight") AS weight, COUNT(*) AS reporters
+                    FROM stew_accounts.reportReasons rr
+                    GROUP BY rr."reportId") w ON w."reportId" = r."id"
+         LEFT JOIN stew_accounts.reportHandlers h ON h."reportId" = r."id"
+WHERE NOT EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = r."id")
+  AND (inTeamId IS NULL OR r."assignedTeam" = inTeamId)
+  AND (inReportId IS NULL OR r."id" = inReportId)
+ORDER BY COALESCE(w.weight, 0) DESC, r."createdTime", r."id";
+$$ LANGUAGE sql STABLE;
+
+
+-- The team the player handles reports for. ST007: the player is on no team.
+CREATE OR REPLACE FUNCTION stew_accounts.reportHandlerTeam(IN inHandlerUUID uuid)
+    RETURNS SMALLINT AS
+$$
+DECLARE
+    teamId SMALLINT;
+BEGIN
+    SELECT m."teamId"
+    INTO teamId
+    FROM stew_accounts.reportTeamMemberships m
+    WHERE m."playerUUID" = inHandlerUUID;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION '% is not on a report team', inHandlerUUID USING ERRCODE = 'ST007';
+    END IF;
+    RETURN teamId;
+END
+$$ LANGUAGE plpgsql STABLE;
+
+
+-- Claims the given report, or the heaviest unclaimed one in the handler's team queue, and returns it.
+-- Returns nothing when the queue is empty.
+-- ST002: unknown report. ST007: the report belongs to another team. ST008: the report is closed or claimed.
+CREATE OR REPLACE FUNCTION stew_accounts.claimReport(IN inHandlerUUID uuid, IN inReportId BIGINT)
+    RETURNS TABLE
+            (
+                id          BIGINT,
+                suspectUUID uuid,
+                suspectName VARCHAR(16),
+                categoryId  SMALLINT,
+                teamId      SMALLINT,
+                weight      BIGINT,
+                reporters   BIGINT,
+                snapshotId  BIGINT,
+                handlerUUID uuid,
+                createdTime TIMESTAMP
+            )
+AS
+$$
+DECLARE
+    handlerTeam   SMALLINT;
+    claimedId     BIGINT;
+    reportTeam    BIGINT;
+    reportClosed  BOOLEAN;
+    reportHandler uuid;
+BEGIN
+    handlerTeam := stew_accounts.reportHandlerTeam(inHandlerUUID);
+
+    IF inReportId IS NULL THEN
+        SELECT r."id"
+        INTO claimedId
+        FROM stew_accounts.reports r
+                 LEFT JOIN stew_accounts.reportHandlers h ON h."reportId" = r."id"
+        WHERE r."assignedTeam" = handlerTeam
+          AND (h."reportId" IS NULL OR h."aborted")
+          AND NOT EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = r."id")
+        ORDER BY (SELECT COALESCE(SUM(rr."weight"), 0)
+                  FROM stew_accounts.reportReasons rr
+                  WHERE rr."reportId" = r."id") DESC, r."createdTime", r."id"
+        LIMIT 1
+            FOR UPDATE OF r SKIP LOCKED;
+        IF NOT FOUND THEN
+            RETURN;
+        END IF;
+    ELSE
+        SELECT r."id",
+               r."assignedTeam",
+               EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = r."id"),
+               CASE WHEN h."aborted" THEN NULL ELSE h."handlerUUID" END
+        INTO claimedId, reportTeam, reportClosed, reportHandler
+        FROM stew_accounts.reports r
+                 LEFT JOIN stew_accounts.reportHandlers h ON h."reportId" = r."id"
+        WHERE r."id" = inReportId
+            FOR UPDATE OF r;
+        IF NOT FOUND THEN
+            RAISE EXCEPTION 'unknown report %', inReportId USING ERRCODE = 'ST002';
+        END IF;
+        IF reportTeam <> handlerTeam THEN
+            RAISE EXCEPTION '% is not on the team of report %', inHandlerUUID, inReportId USING ERRCODE = 'ST007';
+        END IF;
+        IF reportClosed OR (reportHandler IS NOT NULL AND reportHandler <> inHandlerUUID) THEN
+            RAISE EXCEPTION 'report % is not available', inReportId USING ERRCODE = 'ST008';
+        END IF;
+    END IF;
+
+    INSERT INTO stew_accounts.reportHandlers ("reportId", "handlerUUID", "aborted", "claimedTime")
+    VALUES (claimedId, inHandlerUUID, false, CURRENT_TIMESTAMP)
+    ON CONFLICT ("reportId") DO UPDATE SET "handlerUUID" = inHandlerUUID,
+                                           "aborted"     = false,
+                                           "claimedTime" = CURRENT_TIMESTAMP;
+
+    RETURN QUERY SELECT * FROM stew_accounts.getOpenReports(NULL, claimedId);
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Locks an open report that the handler has claimed and may still work on.
+-- ST002: unknown report. ST007: the handler left the report's team. ST008: the report is closed or not claimed by them.
+CREATE OR REPLACE FUNCTION stew_accounts.lockHandledReport(IN inHandlerUUID uuid, IN inReportId BIGINT)
+    RETURNS VOID AS
+$$
+DECLARE
+    reportTeam BIGINT;
+BEGIN
+    SELECT r."assignedTeam"
+    INTO reportTeam
+    FROM stew_accounts.reports r
+    WHERE r."id" = inReportId
+        FOR UPDATE;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'unknown report %', inReportId USING ERRCODE = 'ST002';
+    END IF;
+    IF reportTeam <> stew_accounts.reportHandlerTeam(inHandlerUUID) THEN
+        RAISE EXCEPTION '% is not on the team of report %', inHandlerUUID, inReportId USING ERRCODE = 'ST007';
+    END IF;
+    IF EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = inReportId)
+        OR NOT EXISTS(SELECT 1
+                      FROM stew_accounts.reportHandlers h
+                      WHERE h."reportId" = inReportId
+                        AND h."handlerUUID" = inHandlerUUID
+                        AND NOT h."aborted") THEN
+        RAISE EXCEPTION 'report % is not claimed by %', inReportId, inHandlerUUID USING ERRCODE = 'ST008';
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Puts the report back into the team queue.
+CREATE OR REPLACE FUNCTION stew_accounts.abortReport(IN inHandlerUUID uuid, IN inReportId BIGINT)
+    RETURNS VOID AS
+$$
+BEGIN
+    PERFORM stew_accounts.lockHandledReport(inHandlerUUID, inReportId);
+
+    UPDATE stew_accounts.reportHandlers
+    SET "aborted" = true
+    WHERE reportHandlers."reportId" = inReportId;
+END
+$$ LANGUAGE plpgsql;
+
+
+CREATE OR REPLACE FUNCTION stew_accounts.closeReport(IN inHandlerUUID uuid, IN inReportId BIGINT, IN inResultTypeId SMALLINT,
+                                                    IN inReason TEXT, OUT resultId BIGINT)
+AS
+$$
+BEGIN
+    PERFORM stew_accounts.lockHandledReport(inHandlerUUID, inReportId);
+
+    INSERT INTO stew_accounts.reportResults ("reportId", "reason", "resultTypeId", "handlerUUID")
+    VALUES (inReportId, inReason, inResultTypeId, inHandlerUUID)
+    RETURNING reportResults."resultId" INTO resultId;
+END
+$$ LANGUAGE plpgsql;
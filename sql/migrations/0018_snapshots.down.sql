@@ -0,0 +1,13 @@
+DROP FUNCTION IF EXISTS stew_accounts.pruneSnapshotMessages(INT);
+DROP FUNCTION IF EXISTS stew_accounts.getSnapshotMessages(BIGINT);
+DROP FUNCTION IF EXISTS stew_accounts.createReportSnapshot(BIGINT, uuid, INT, INT);
+DROP FUNCTION IF EXISTS stew_accounts.addSnapshotMessages(VARCHAR, uuid[], BIGINT[], TEXT[], SMALLINT[], TEXT[]);
+DROP INDEX IF EXISTS stew_accounts.snapshotMessageMap_message_idx;
+DROP INDEX IF EXISTS stew_accounts.snapshotRecipients_recipient_idx;
+DROP INDEX IF EXISTS stew_accounts.snapshotMessages_time_idx;
+DROP INDEX IF EXISTS stew_accounts.snapshotMessages_sender_idx;
+ALTER TABLE stew_accounts.snapshots
+    DROP COLUMN "createdTime";
+ALTER TABLE stew_accounts.snapshotRecipients
+    DROP CONSTRAINT IF EXISTS snapshotRecipients_messageId_fkey;
+-- The snapshot types are left in place, as stored messages still refer to them.
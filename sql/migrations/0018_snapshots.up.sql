@@ -0,0 +1,169 @@
+INSERT INTO stew_accounts.snapshotTypes ("id", "name")
+VALUES (1, 'CHAT'),
+       (2, 'PRIVATE_MESSAGE'),
+       (3, 'PARTY')
+ON CONFLICT ("id") DO NOTHING;
+
+-- Recipients go with their message when it is pruned.
+ALTER TABLE stew_accounts.snapshotRecipients
+    ADD CONSTRAINT snapshotRecipients_messageId_fkey FOREIGN KEY ("messageId")
+        REFERENCES stew_accounts.snapshotMessages ("messageId") ON DELETE CASCADE NOT VALID;
+
+ALTER TABLE stew_accounts.snapshots
+    ADD COLUMN "createdTime" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX snapshotMessages_sender_idx ON stew_accounts.snapshotMessages ("senderUUID", "time");
+CREATE INDEX snapshotMessages_time_idx ON stew_accounts.snapshotMessages ("time");
+CREATE INDEX snapshotRecipients_recipient_idx ON stew_accounts.snapshotRecipients ("recipientUUID");
+CREATE INDEX snapshotMessageMap_message_idx ON stew_accounts.snapshotMessageMap ("messageId");
+
+
+-- The arrays are parallel. inTimes holds unix milliseconds and may be null, in which case every message is stamped now.
+-- inRecipients holds a comma separated list of UUIDs per message, or an empty string.
+CREATE OR REPLACE FUNCTION stew_accounts.addSnapshotMessages(
+    IN inServer VARCHAR(30),
+    IN inSenders uuid[],
+    IN inTimes BIGINT[],
+    IN inMessages TEXT[],
+    IN inTypes SMALLINT[],
+    IN inRecipients TEXT[],
+    OUT added INT)
+AS
+$$
+DECLARE
+    i         INT;
+    messageId BIGINT;
+BEGIN
+    added := 0;
+    FOR i IN 1..COALESCE(array_length(inSenders, 1), 0)
+        LOOP
+            INSERT INTO stew_accounts.snapshotMessages ("senderUUID", "server", "time", "message", "snapshotType")
+            VALUES (inSenders[i],
+                    inServer,
+                    CASE
+                        WHEN inTimes IS NULL THEN LOCALTIMESTAMP
+                        ELSE to_timestamp(inTimes[i] / 1000.0)::TIMESTAMP END,
+                    inMessages[i],
+                    inTypes[i])
+            RETURNING snapshotMessages."messageId" INTO messageId;
+
+            INSERT INTO stew_accounts.snapshotRecipients ("messageId", "recipientUUID")
+            SELECT DISTINCT messageId, r::uuid
+            FROM unnest(string_to_array(NULLIF(inRecipients[i], ''), ',')) r;
+
+            added := added + 1;
+        END LOOP;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- Captures what the suspect sent, and the private messages they received, over the last inMinutes
+-- (at most inLimit messages, newest first), and attaches the snapshot to the report.
+-- A report that already has a snapshot gets the new one instead, and the old one is deleted so its messages can be pruned.
+-- ST002: unknown report. ST008: the report is closed.
+CREATE OR REPLACE FUNCTION stew_accounts.createReportSnapshot(IN inReportId BIGINT, IN inCreatorUUID uuid,
+                                                             IN inMinutes INT, IN inLimit INT,
+                                                             OUT snapshotId BIGINT, OUT messages INT)
+AS
+$$
+DECLARE
+    suspectUUID        uuid;
+    previousSnapshotId BIGINT;
+BEGIN
+    SELECT r."suspectUUID", r."snapshotId"
+    INTO suspectUUID, previousSnapshotId
+    FROM stew_accounts.reports r
+    WHERE r."id" = inReportId
+        FOR UPDATE;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'unknown report %', inReportId USING ERRCODE = 'ST002';
+    END IF;
+    IF EXISTS(SELECT 1 FROM stew_accounts.reportResults res WHERE res."reportId" = inReportId) THEN
+        RAISE EXCEPTION 'report % is closed', inReportId USING ERRCODE = 'ST008';
+    END IF;
+
+    INSERT INTO stew_accounts.snapshots ("creatorUUID")
+    VALUES (inCreatorUUID)
+    RETURNING snapshots."id" INTO snapshotId;
+
+    INSERT INTO stew_accounts.snapshotMessageMap ("snapshotId", "messageId")
+    SELECT createReportSnapshot.snapshotId, m."messageId"
+    FROM stew_accounts.snapshotMessages m
+    WHERE m."time" >= LOCALTIMESTAMP - inMinutes * INTERVAL '1 minute'
+      AND (m."senderUUID" = suspectUUID OR
+           (m."snapshotType" = 2 AND EXISTS(SELECT 1
+                                            FROM stew_accounts.snapshotRecipients sr
+                                            WHERE sr."messageId" = m."messageId"
+                                              AND sr."recipientUUID" = suspectUUID)))
+    ORDER BY m."time" DESC, m."messageId" DESC
+    LIMIT inLimit;
+    GET DIAGNOSTICS messages = ROW_COUNT;
+
+    UPDATE stew_accounts.reports
+    SET "snapshotId" = createReportSnapshot.snapshotId
+    WHERE reports."id" = inReportId;
+
+    IF previousSnapshotId IS NOT NULL AND NOT EXISTS(SELECT 1
+                                                     FROM stew_accounts.reports r
+                                                     WHERE r."snapshotId" = previousSnapshotId) THEN
+        DELETE FROM stew_accounts.snapshotMessageMap mm WHERE mm."snapshotId" = previousSnapshotId;
+        DELETE FROM stew_accounts.snapshots s WHERE s."id" = previousSnapshotId;
+    END IF;
+END
+$$ LANGUAGE plpgsql;
+
+
+-- The snapshot's messages oldest first. ST002: unknown snapshot.
+CREATE OR REPLACE FUNCTION stew_accounts.getSnapshotMessages(IN inSnapshotId BIGINT)
+    RETURNS TABLE
+            (
+                messageId  BIGINT,
+                senderUUID uuid,
+                senderName VARCHAR(16),
+                server     VARCHAR(30),
+                sentTime   TIMESTAMP,
+                message    TEXT,
+                type       VARCHAR(25),
+                recipients uuid[]
+            )
+AS
+$$
+BEGIN
+    PERFORM 1 FROM stew_accounts.snapshots s WHERE s."id" = inSnapshotId;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'unknown snapshot %', inSnapshotId USING ERRCODE = 'ST002';
+    END IF;
+
+    RETURN QUERY
+        SELECT m."messageId",
+               m."senderUUID",
+               a."name",
+               m."server",
+               m."time",
+               m."message",
+               t."name",
+               COALESCE((SELECT array_agg(sr."recipientUUID" ORDER BY sr."recipientUUID")
+                         FROM stew_accounts.snapshotRecipients sr
+                         WHERE sr."messageId" = m."messageId"), '{}'::uuid[])
+        FROM stew_accounts.snapshotMessageMap mm
+                 INNER JOIN stew_accounts.snapshotMessages m ON m."messageId" = mm."messageId"
+                 INNER JOIN stew_accounts.accounts a ON a."uuid" = m."senderUUID"
+                 INNER JOIN stew_accounts.snapshotTypes t ON t."id" = m."snapshotType"
+        WHERE mm."snapshotId" = inSnapshotId
+        ORDER BY m."time", m."messageId";
+END
+$$ LANGUAGE plpgsql STABLE;
+
+
+-- Deletes messages older than inDays that no snapshot refers to.
+CREATE OR REPLACE FUNCTION stew_accounts.pruneSnapshotMessages(IN inDays INT, OUT deleted BIGINT)
+AS
+$$
+BEGIN
+    DELETE
+    FROM stew_accounts.snapshotMessages m
+    WHERE m."time" < LOCALTIMESTAMP - inDays * INTERVAL '1 day'
+      AND NOT EXISTS(SELECT 1 FROM stew_accounts.snapshotMessageMap mm WHERE mm."messageId" = m."messageId");
+    GET DIAGNOSTICS deleted = ROW_COUNT;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/database"
+	"stew/router"
+	"stew/routes/v1/gateway"
+	"stew/routes/v1/network"
+	"strings"
+	"testing"
+)
+
+func authRequest(t *testing.T, expectStatus int, method string, path string, key string) {
+	var body *strings.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader(url.Values{"ip": []string{"203.0.113.7"}}.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, path), body)
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+	if key != "" {
+		req.Header.Set(router.AuthorizationHeader, key)
+	}
+	client := &http.Client{Transport: unauthenticatedTransport}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, expectStatus, resp.StatusCode)
+	defer resp.Body.Close()
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gatewayRead, err := database.CreateAPIKey("test-gateway-read", []string{"gateway:read"})
+	require.NoError(t, err)
+	gatewayAll, err := database.CreateAPIKey("test-gateway-all", []string{"gateway:*"})
+	require.NoError(t, err)
+	networkAll, err := database.CreateAPIKey("test-network-all", []string{"network:*"})
+	require.NoError(t, err)
+	revoked, err := database.CreateAPIKey("test-revoked", []string{"*"})
+	require.NoError(t, err)
+	_, err = database.RevokeAPIKey("test-revoked")
+	require.NoError(t, err)
+	router.ReloadAPIKeys()
+
+	ipPath := gateway.RouteGroup + gateway.IpInfoPath + "?ip=203.0.113.7"
+	for _, ent := range []struct {
+		expectStatus int
+		method       string
+		path         string
+		key          string
+	}{
+		{http.StatusUnauthorized, http.MethodGet, ipPath, ""},
+		{http.StatusUnauthorized, http.MethodGet, ipPath, gatewayRead},
+		{http.StatusUnauthorized, http.MethodGet, ipPath, "Bearer stew_invalid"},
+		{http.StatusUnauthorized, http.MethodGet, ipPath, "Bearer " + revoked},
+		{http.StatusOK, http.MethodGet, ipPath, "Bearer " + gatewayRead},
+		{http.StatusForbidden, http.MethodPost, gateway.RouteGroup + gateway.IpInfoPath, "Bearer " + gatewayRead},
+		{http.StatusNoContent, http.MethodPost, gateway.RouteGroup + gateway.IpInfoPath, "Bearer " + gatewayAll},
+		{http.StatusForbidden, http.MethodGet, ipPath, "Bearer " + networkAll},
+		{http.StatusForbidden, http.MethodGet, network.RouteGroup, "Bearer " + gatewayAll},
+		{http.StatusNotFound, http.MethodGet, network.RouteGroup, "Bearer " + networkAll},
+	} {
+		t.Run(fmt.Sprintf("API key auth %s %s %d", ent.method, ent.path, ent.expectStatus), func(tt *testing.T) {
+			authRequest(tt, ent.expectStatus, ent.method, ent.path, ent.key)
+		})
+	}
+}
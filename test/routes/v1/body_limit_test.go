@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"stew/router"
+	"stew/routes/v1/gateway"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	for _, ent := range []struct {
+		expectStatus int
+		size         int
+	}{
+		{http.StatusBadRequest, 1 << 10},
+		{http.StatusRequestEntityTooLarge, 2 << 20},
+	} {
+		t.Run(fmt.Sprintf("Body limit %d bytes", ent.size), func(tt *testing.T) {
+			addIpInfoInvalid(tt, ent.expectStatus, "application/x-www-form-urlencoded", bytes.NewReader(bytes.Repeat([]byte("a"), ent.size)))
+		})
+	}
+
+	t.Run("Body limit without content length", func(tt *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s:%d%s",
+			router.ListenAddr, router.ListenPort, gateway.RouteGroup+gateway.IpInfoPath),
+			struct{ *bytes.Reader }{bytes.NewReader(bytes.Repeat([]byte("a"), 2<<20))})
+		require.NoError(tt, err)
+		req.Header.Set("content-type", "application/x-www-form-urlencoded")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(tt, err)
+		require.Equal(tt, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		defer resp.Body.Close()
+	})
+}
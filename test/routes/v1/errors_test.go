@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/router"
+	"stew/routes/v1/gateway"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func getErrorResponse(t *testing.T, client *http.Client, expectStatus int, path string) types.ErrorResponse {
+	resp, err := client.Get(fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, path))
+	require.NoError(t, err)
+	require.Equal(t, expectStatus, resp.StatusCode)
+	defer resp.Body.Close()
+
+	res := types.ErrorResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, resp.Header.Get(router.RequestIdHeader), res.Error.RequestId)
+	require.NotEmpty(t, res.Error.Message)
+	return res
+}
+
+func TestErrorResponses(t *testing.T) {
+	for _, ent := range []struct {
+		expectStatus int
+		path         string
+		code         string
+		field        string
+		client       *http.Client
+	}{
+		{http.StatusBadRequest, gateway.RouteGroup + gateway.PlayerInfoPath + "?uuid=invalid", constants.ErrorInvalidField, "uuid", http.DefaultClient},
+		{http.StatusBadRequest, gateway.RouteGroup + gateway.IpInfoPath + "?ip=", constants.ErrorMissingField, "ip", http.DefaultClient},
+		{http.StatusBadRequest, gateway.RouteGroup + gateway.IpInfoPath + "?ip=224.0.0.1", constants.ErrorInvalidField, "ip", http.DefaultClient},
+		{http.StatusNotFound, gateway.RouteGroup, constants.ErrorNotFound, "", http.DefaultClient},
+		{http.StatusNotFound, "/does/not/exist", constants.ErrorNotFound, "", http.DefaultClient},
+		{http.StatusMethodNotAllowed, network.RouteGroup + network.FriendRequestPath, constants.ErrorMethodNotAllowed, "", http.DefaultClient},
+		{http.StatusUnauthorized, gateway.RouteGroup + gateway.IpInfoPath + "?ip=1.2.3.4", constants.ErrorUnauthorized, "", &http.Client{Transport: unauthenticatedTransport}},
+	} {
+		t.Run(fmt.Sprintf("Error response %s %s", ent.path, ent.code), func(tt *testing.T) {
+			res := getErrorResponse(tt, ent.client, ent.expectStatus, ent.path)
+			require.Equal(tt, ent.code, res.Error.Code)
+			require.Equal(tt, ent.field, res.Error.Field)
+		})
+	}
+}
+
+func postErrorResponse(t *testing.T, expectStatus int, path string, values url.Values) types.ErrorResponse {
+	resp, err := http.PostForm(fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, path), values)
+	require.NoError(t, err)
+	require.Equal(t, expectStatus, resp.StatusCode)
+	defer resp.Body.Close()
+
+	res := types.ErrorResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	require.NoError(t, err)
+	return res
+}
+
+func TestDatabaseErrorResponses(t *testing.T) {
+	uuid := "5c7d3f0e-8a41-4c3b-9e2f-0b6a1d4e7c92"
+	addPlayerInfo(t, http.StatusNoContent, uuid, "Duplicate_Me", "47")
+
+	t.Run("Duplicate player info", func(tt *testing.T) {
+		res := postErrorResponse(tt, http.StatusConflict, gateway.RouteGroup+gateway.PlayerInfoPath, url.Values{
+			"uuid":    []string{uuid},
+			"name":    []string{"Duplicate_Me"},
+			"version": []string{"47"},
+		})
+		require.Equal(tt, constants.ErrorAlreadyExists, res.Error.Code)
+		require.Equal(tt, "uuid", res.Error.Field)
+	})
+
+	addIpInfo(t, http.StatusNoContent, "203.0.113.20")
+	ips := getIpInfo(t, http.StatusOK, "203.0.113.20")
+	require.GreaterOrEqual(t, len(ips), 1)
+
+	t.Run("Login unknown player", func(tt *testing.T) {
+		res := postErrorResponse(tt, http.StatusNotFound, gateway.RouteGroup+gateway.PlayerLoginPath, url.Values{
+			"uuid": []string{"9b2e4d6a-1f3c-4a5b-8c7d-e6f5a4b3c2d1"},
+			"ipid": []string{strconv.FormatInt(ips[0].Id, 10)},
+		})
+		require.Equal(tt, constants.ErrorUnknownReference, res.Error.Code)
+		require.Equal(tt, "uuid", res.Error.Field)
+	})
+}
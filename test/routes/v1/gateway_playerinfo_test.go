@@ -65,6 +65,18 @@ func getPlayerInfo(t *testing.T, expectStatus int, uuid string) *types.PlayerInf
 	return player
 }
 
+// Like getPlayerInfo, but returns nil instead of failing for players that do not exist yet.
+func findPlayerInfo(t *testing.T, uuid string) *types.PlayerInfoResponse {
+	resp, err := http.Get(fmt.Sprintf("http://%s:%d%s?uuid=%s",
+		router.ListenAddr, router.ListenPort, gateway.RouteGroup+gateway.PlayerInfoPath, uuid))
+	require.NoError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return getPlayerInfo(t, http.StatusOK, uuid)
+}
+
 func getPlayerInfoInvalid(t *testing.T, expectStatus int, field string, value string) {
 	resp, err := http.Get(fmt.Sprintf("http://%s:%d%s?%s=%s",
 		router.ListenAddr, router.ListenPort, gateway.RouteGroup+gateway.PlayerInfoPath, field, value))
@@ -206,6 +218,9 @@ func TestGetPlayerInfo(t *testing.T) {
 			require.True(tt, strings.EqualFold(us, u.uuid))
 		})
 	}
+	t.Run("Get player info unknown uuid", func(tt *testing.T) {
+		require.Nil(tt, getPlayerInfo(tt, http.StatusNotFound, "0f1e2d3c-4b5a-4968-8778-695a4b3c2d1e"))
+	})
 	for _, u := range invalidEntriesValidUUID {
 		t.Run(fmt.Sprintf("Get player info invalid entry valid uuid %s %s %s", u.uuid, u.name, u.version), func(tt *testing.T) {
 			player := getPlayerInfo(tt, http.StatusOK, u.uuid)
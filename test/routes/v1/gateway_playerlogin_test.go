@@ -95,7 +95,7 @@ func TestPlayerLogin(t *testing.T) {
 						ips = getIpInfo(tt, http.StatusOK, ipAddress)
 						require.GreaterOrEqual(tt, len(ips), 1)
 					}
-					player := getPlayerInfo(tt, http.StatusOK, uuid)
+					player := findPlayerInfo(tt, uuid)
 
 					if player == nil {
 						addPlayerInfo(tt, http.StatusNoContent, uuid, name, version)
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"stew/router"
+	"stew/routes/health"
+	"stew/types"
+	"testing"
+)
+
+func TestLiveness(t *testing.T) {
+	client := &http.Client{Transport: unauthenticatedTransport}
+	resp, err := client.Get(fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, health.LivenessPath))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	defer resp.Body.Close()
+
+	res := types.HealthResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Status)
+}
+
+func TestReadiness(t *testing.T) {
+	client := &http.Client{Transport: unauthenticatedTransport}
+	resp, err := client.Get(fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, health.ReadinessPath))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	defer resp.Body.Close()
+
+	res := types.ReadinessResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.Status)
+	require.Equal(t, "ok", res.Database.Status)
+	require.Equal(t, "ok", res.Migrations.Status)
+	require.Equal(t, 0, res.Migrations.Pending)
+	require.Equal(t, res.Migrations.LatestVersion, res.Migrations.CurrentVersion)
+	require.GreaterOrEqual(t, res.Pool.TotalConns, int32(1))
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"stew/router"
+	"stew/routes/health"
+	"stew/routes/v1/gateway"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	getIpInfo(t, http.StatusOK, "203.0.113.9")
+
+	client := &http.Client{Transport: unauthenticatedTransport}
+	resp, err := client.Get(fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, health.MetricsPath))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	for _, metric := range []string{
+		fmt.Sprintf(`stew_http_requests_total{method="GET",route="%s",status="200"}`, gateway.RouteGroup+gateway.IpInfoPath),
+		"stew_http_request_duration_seconds_bucket",
+		"stew_db_pool_total_conns",
+		"stew_gateway_player_logins_total",
+	} {
+		require.Contains(t, string(body), metric)
+	}
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/url"
+	"stew/router"
+	"stew/routes/v1/network"
+	"stew/types"
+	globalUtils "stew/utils"
+	"strings"
+	"testing"
+)
+
+// Sends `form` url-encoded and decodes a 2xx JSON answer into `out` when it is not nil.
+func networkRequest(t *testing.T, expectStatus int, method string, path string, query url.Values, form url.Values, out any) {
+	target := fmt.Sprintf("http://%s:%d%s", router.ListenAddr, router.ListenPort, network.RouteGroup+path)
+	if query != nil {
+		target += "?" + query.Encode()
+	}
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, target, body)
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, expectStatus, resp.StatusCode)
+	if out != nil && expectStatus < http.StatusMultipleChoices {
+		err = json.NewDecoder(resp.Body).Decode(out)
+		require.NoError(t, err)
+	}
+}
+
+func joinAccount(t *testing.T, uuid string, name string) types.AccountResponse {
+	account := types.AccountResponse{}
+	networkRequest(t, http.StatusOK, http.MethodPost, network.AccountJoinPath, nil, url.Values{
+		"uuid": []string{uuid},
+		"name": []string{name},
+	}, &account)
+	require.True(t, strings.EqualFold(globalUtils.PGUUIDToString(account.UUID), uuid))
+	return account
+}
+
+func getAccount(t *testing.T, expectStatus int, query url.Values) types.AccountResponse {
+	account := types.AccountResponse{}
+	networkRequest(t, expectStatus, http.MethodGet, network.AccountPath, query, nil, &account)
+	return account
+}
+
+func TestAccounts(t *testing.T) {
+	uuid := "3f1c2b4a-5d6e-4f70-8a9b-0c1d2e3f4a5b"
+
+	t.Run("Create account", func(tt *testing.T) {
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.AccountPath, nil, url.Values{
+			"uuid": []string{uuid},
+			"name": []string{"Stew_Eater"},
+		}, nil)
+		networkRequest(tt, http.StatusConflict, http.MethodPost, network.AccountPath, nil, url.Values{
+			"uuid": []string{uuid},
+			"name": []string{"Stew_Eater"},
+		}, nil)
+	})
+
+	t.Run("Get account", func(tt *testing.T) {
+		account := getAccount(tt, http.StatusOK, url.Values{"uuid": []string{uuid}})
+		require.Equal(tt, "Stew_Eater", account.Name)
+		require.False(tt, account.LastLogin.Valid)
+
+		account = getAccount(tt, http.StatusOK, url.Values{"name": []string{"stew_eater"}})
+		require.True(tt, strings.EqualFold(globalUtils.PGUUIDToString(account.UUID), uuid))
+
+		getAccount(tt, http.StatusNotFound, url.Values{"uuid": []string{"0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b"}})
+		getAccount(tt, http.StatusNotFound, url.Values{"name": []string{"Nobody_Here"}})
+		getAccount(tt, http.StatusBadRequest, url.Values{})
+		getAccount(tt, http.StatusBadRequest, url.Values{"name": []string{"no-dashes"}})
+	})
+
+	t.Run("Join account", func(tt *testing.T) {
+		account := joinAccount(tt, uuid, "Stew_Lover")
+		require.Equal(tt, "Stew_Lover", account.Name)
+		require.True(tt, account.LastLogin.Valid)
+
+		account = joinAccount(tt, "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", "First_Joiner")
+		require.Equal(tt, int64(0), account.Coins)
+	})
+
+	t.Run("Rename account", func(tt *testing.T) {
+		networkRequest(tt, http.StatusNoContent, http.MethodPatch, network.AccountPath,
+			url.Values{"uuid": []string{uuid}}, url.Values{"name": []string{"Stew_Renamed"}}, nil)
+		require.Equal(tt, "Stew_Renamed", getAccount(tt, http.StatusOK, url.Values{"uuid": []string{uuid}}).Name)
+
+		networkRequest(tt, http.StatusNotFound, http.MethodPatch, network.AccountPath,
+			url.Values{"uuid": []string{"0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b"}}, url.Values{"name": []string{"Ghost"}}, nil)
+	})
+
+	t.Run("Add play time", func(tt *testing.T) {
+		for _, minutes := range []string{"15", "30"} {
+			networkRequest(tt, http.StatusNoContent, http.MethodPost, network.AccountPlayTimePath, nil,
+				url.Values{"uuid": []string{uuid}, "minutes": []string{minutes}}, nil)
+		}
+		require.Equal(tt, 45, getAccount(tt, http.StatusOK, url.Values{"uuid": []string{uuid}}).TotalPlayTime)
+
+		for _, minutes := range []string{"0", "-5", "abc", "99999999999"} {
+			expect := http.StatusBadRequest
+			if minutes == "99999999999" {
+				expect = http.StatusUnprocessableEntity
+			}
+			networkRequest(tt, expect, http.MethodPost, network.AccountPlayTimePath, nil,
+				url.Values{"uuid": []string{uuid}, "minutes": []string{minutes}}, nil)
+		}
+	})
+}
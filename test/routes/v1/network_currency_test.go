@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func changeCurrency(t *testing.T, expectStatus int, path string, form url.Values) types.CurrencyChangeResponse {
+	res := types.CurrencyChangeResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, path, nil, form, &res)
+	return res
+}
+
+func getCurrencyHistory(t *testing.T, expectStatus int, query url.Values) types.CurrencyHistoryResponse {
+	res := types.CurrencyHistoryResponse{}
+	networkRequest(t, expectStatus, http.MethodGet, network.CurrencyHistoryPath, query, nil, &res)
+	return res
+}
+
+func TestCurrency(t *testing.T) {
+	alice := "0d4c1a6e-7b2f-4e8a-9c3d-5f6a7b8c9d0e"
+	bob := "7e6d5c4b-3a29-4f18-a7b6-c5d4e3f2a1b0"
+	joinAccount(t, alice, "Coin_Hoarder")
+	joinAccount(t, bob, "Coin_Spender")
+
+	t.Run("Credit", func(tt *testing.T) {
+		res := changeCurrency(tt, http.StatusOK, network.CurrencyCreditPath, url.Values{
+			"uuid":           []string{alice},
+			"currency":       []string{constants.CurrencyCoins},
+			"amount":         []string{"100"},
+			"reason":         []string{"Daily reward"},
+			"server":         []string{"lobby-1"},
+			"idempotencyKey": []string{"daily:alice:1"},
+		})
+		require.Equal(tt, int64(100), res.Balance)
+		require.False(tt, res.Replayed)
+
+		replay := changeCurrency(tt, http.StatusOK, network.CurrencyCreditPath, url.Values{
+			"uuid":           []string{alice},
+			"currency":       []string{constants.CurrencyCoins},
+			"amount":         []string{"100"},
+			"idempotencyKey": []string{"daily:alice:1"},
+		})
+		require.True(tt, replay.Replayed)
+		require.Equal(tt, res.TransactionId, replay.TransactionId)
+		require.Equal(tt, int64(100), getAccount(tt, http.StatusOK, url.Values{"uuid": []string{alice}}).Coins)
+
+		res = changeCurrency(tt, http.StatusOK, network.CurrencyCreditPath, url.Values{
+			"uuid":     []string{alice},
+			"currency": []string{constants.CurrencyGems},
+			"amount":   []string{"5"},
+			"actor":    []string{bob},
+		})
+		require.Equal(tt, int64(5), res.Balance)
+
+		changeCurrency(tt, http.StatusNotFound, network.CurrencyCreditPath, url.Values{
+			"uuid":     []string{"0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b"},
+			"currency": []string{constants.CurrencyCoins},
+			"amount":   []string{"1"},
+		})
+		for _, form := range []url.Values{
+			{"uuid": []string{alice}, "currency": []string{"diamonds"}, "amount": []string{"1"}},
+			{"uuid": []string{alice}, "currency": []string{constants.CurrencyCoins}, "amount": []string{"0"}},
+			{"uuid": []string{alice}, "currency": []string{constants.CurrencyCoins}, "amount": []string{"-1"}},
+			{"uuid": []string{alice}, "currency": []string{constants.CurrencyCoins}, "amount": []string{"1"}, "server": []string{"no spaces"}},
+		} {
+			changeCurrency(tt, http.StatusBadRequest, network.CurrencyCreditPath, form)
+		}
+	})
+
+	t.Run("Debit", func(tt *testing.T) {
+		res := changeCurrency(tt, http.StatusOK, network.CurrencyDebitPath, url.Values{
+			"uuid":     []string{alice},
+			"currency": []string{constants.CurrencyCoins},
+			"amount":   []string{"40"},
+			"reason":   []string{"Kit purchase"},
+		})
+		require.Equal(tt, int64(60), res.Balance)
+
+		changeCurrency(tt, http.StatusConflict, network.CurrencyDebitPath, url.Values{
+			"uuid":     []string{alice},
+			"currency": []string{constants.CurrencyCoins},
+			"amount":   []string{"61"},
+		})
+		require.Equal(tt, int64(60), getAccount(tt, http.StatusOK, url.Values{"uuid": []string{alice}}).Coins)
+	})
+
+	t.Run("Transfer", func(tt *testing.T) {
+		transfer := url.Values{
+			"from":           []string{alice},
+			"to":             []string{bob},
+			"currency":       []string{constants.CurrencyCoins},
+			"amount":         []string{"25"},
+			"idempotencyKey": []string{"gift-1"},
+		}
+		res := types.CurrencyTransferResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.CurrencyTransferPath, nil, transfer, &res)
+		require.Equal(tt, int64(35), res.FromBalance)
+		require.Equal(tt, int64(25), res.ToBalance)
+		require.False(tt, res.Replayed)
+
+		replay := types.CurrencyTransferResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.CurrencyTransferPath, nil, transfer, &replay)
+		require.True(tt, replay.Replayed)
+		require.Equal(tt, res, types.CurrencyTransferResponse{
+			TransactionId: replay.TransactionId,
+			FromBalance:   replay.FromBalance,
+			ToBalance:     replay.ToBalance,
+		})
+
+		networkRequest(tt, http.StatusConflict, http.MethodPost, network.CurrencyTransferPath, nil, url.Values{
+			"from":     []string{bob},
+			"to":       []string{alice},
+			"currency": []string{constants.CurrencyCoins},
+			"amount":   []string{"26"},
+		}, nil)
+		networkRequest(tt, http.StatusBadRequest, http.MethodPost, network.CurrencyTransferPath, nil, url.Values{
+			"from":     []string{alice},
+			"to":       []string{alice},
+			"currency": []string{constants.CurrencyCoins},
+			"amount":   []string{"1"},
+		}, nil)
+		require.Equal(tt, int64(25), getAccount(tt, http.StatusOK, url.Values{"uuid": []string{bob}}).Coins)
+	})
+
+	t.Run("History", func(tt *testing.T) {
+		all := getCurrencyHistory(tt, http.StatusOK, url.Values{"uuid": []string{alice}})
+		require.Len(tt, all.Transactions, 4)
+		require.Nil(tt, all.NextBefore)
+		require.Equal(tt, int64(-25), all.Transactions[0].Amount)
+		require.True(tt, all.Transactions[0].CounterpartyUUID.Valid)
+		require.Equal(tt, "Daily reward", all.Transactions[3].Reason)
+		require.Equal(tt, "lobby-1", all.Transactions[3].Server)
+
+		gems := getCurrencyHistory(tt, http.StatusOK, url.Values{
+			"uuid":     []string{alice},
+			"currency": []string{constants.CurrencyGems},
+		})
+		require.Len(tt, gems.Transactions, 1)
+		require.True(tt, gems.Transactions[0].ActorUUID.Valid)
+
+		page := getCurrencyHistory(tt, http.StatusOK, url.Values{"uuid": []string{alice}, "limit": []string{"3"}})
+		require.Len(tt, page.Transactions, 3)
+		require.NotNil(tt, page.NextBefore)
+		page = getCurrencyHistory(tt, http.StatusOK, url.Values{
+			"uuid":   []string{alice},
+			"limit":  []string{"3"},
+			"before": []string{strconv.FormatInt(*page.NextBefore, 10)},
+		})
+		require.Len(tt, page.Transactions, 1)
+		require.Equal(tt, all.Transactions[3].Id, page.Transactions[0].Id)
+
+		getCurrencyHistory(tt, http.StatusBadRequest, url.Values{})
+		getCurrencyHistory(tt, http.StatusBadRequest, url.Values{"uuid": []string{alice}, "limit": []string{"0"}})
+	})
+}
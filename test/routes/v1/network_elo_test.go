@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func getElo(t *testing.T, uuid string, game string) types.EloResponse {
+	res := types.EloResponse{}
+	networkRequest(t, http.StatusOK, http.MethodGet, network.AccountEloPath, url.Values{
+		"uuid": []string{uuid},
+		"game": []string{game},
+	}, nil, &res)
+	return res
+}
+
+func recordEloMatch(t *testing.T, expectStatus int, game string, players []string, teams []string, placements []string) []types.EloChangeResponse {
+	var res []types.EloChangeResponse
+	networkRequest(t, expectStatus, http.MethodPost, network.EloMatchPath, nil, url.Values{
+		"game":      []string{game},
+		"uuid":      players,
+		"team":      teams,
+		"placement": placements,
+	}, &res)
+	return res
+}
+
+func TestElo(t *testing.T) {
+	alpha := "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b"
+	bravo := "6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8b9c"
+	charlie := "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d"
+	delta := "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e"
+	joinAccount(t, alpha, "Elo_Alpha")
+	joinAccount(t, bravo, "Elo_Bravo")
+	joinAccount(t, charlie, "Elo_Charlie")
+	joinAccount(t, delta, "Elo_Delta")
+
+	initial := getElo(t, alpha, "3")
+	require.False(t, initial.Rated)
+
+	t.Run("Match", func(tt *testing.T) {
+		res := recordEloMatch(tt, http.StatusOK, "3",
+			[]string{alpha, bravo, charlie, delta}, []string{"1", "1", "2", "2"}, []string{"1", "1", "2", "2"})
+		require.Len(tt, res, 4)
+		for _, change := range res {
+			require.Equal(tt, initial.Elo, change.PreviousElo)
+			if change.Team == 1 {
+				require.Greater(tt, change.Elo, initial.Elo)
+			} else {
+				require.Less(tt, change.Elo, initial.Elo)
+			}
+		}
+
+		winner := getElo(tt, alpha, "3")
+		require.True(tt, winner.Rated)
+		require.Greater(tt, winner.Elo, initial.Elo)
+		require.False(tt, getElo(tt, alpha, "4").Rated)
+
+		res = recordEloMatch(tt, http.StatusOK, "3",
+			[]string{alpha, charlie}, []string{"1", "2"}, []string{"1", "1"})
+		require.Less(tt, res[0].Elo, res[0].PreviousElo)
+		require.Greater(tt, res[1].Elo, res[1].PreviousElo)
+	})
+
+	t.Run("Invalid matches", func(tt *testing.T) {
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, bravo}, []string{"1", "1"}, []string{"1", "1"})
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, alpha}, []string{"1", "2"}, []string{"1", "2"})
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, bravo, charlie}, []string{"1", "1", "2"}, []string{"1", "2", "3"})
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, bravo}, []string{"1"}, []string{"1", "2"})
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, bravo}, []string{"1", "2147483648"}, []string{"1", "2"})
+		recordEloMatch(tt, http.StatusBadRequest, "3", []string{alpha, bravo}, []string{"1", "2"}, []string{"1", "2147483648"})
+		recordEloMatch(tt, http.StatusNotFound, "3",
+			[]string{alpha, "9c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f"}, []string{"1", "2"}, []string{"1", "2"})
+	})
+
+	t.Run("Opponents", func(tt *testing.T) {
+		var res []types.EloOpponentResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.EloOpponentsPath, url.Values{
+			"game":  []string{"3"},
+			"uuid":  []string{alpha},
+			"range": []string{"1000"},
+		}, nil, &res)
+		require.Len(tt, res, 3)
+		for _, opponent := range res {
+			require.NotEqual(tt, "Elo_Alpha", opponent.Name)
+		}
+
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.EloOpponentsPath, url.Values{
+			"game":  []string{"3"},
+			"uuid":  []string{alpha},
+			"range": []string{"0"},
+		}, nil, &res)
+		require.Empty(tt, res)
+	})
+}
@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/config"
+	"stew/constants"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func friendPair(uuid string, target string) url.Values {
+	return url.Values{"uuid": []string{uuid}, "target": []string{target}}
+}
+
+func sendFriendRequest(t *testing.T, expectStatus int, uuid string, target string) string {
+	res := types.FriendRequestResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.FriendRequestPath, nil, friendPair(uuid, target), &res)
+	return res.Status
+}
+
+func getFriends(t *testing.T, uuid string) types.FriendsResponse {
+	res := types.FriendsResponse{}
+	networkRequest(t, http.StatusOK, http.MethodGet, network.FriendsPath, url.Values{"uuid": []string{uuid}}, nil, &res)
+	return res
+}
+
+func TestFriends(t *testing.T) {
+	// Deliberately out of order so both sides of the uuidSource < uuidTarget pair get exercised.
+	steve := "f1e2d3c4-b5a6-4978-8a6b-5c4d3e2f1a0b"
+	alex := "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+	herobrine := "8c9d0e1f-2a3b-4c4d-9e5f-6a7b8c9d0e1f"
+	notch := "3e4f5a6b-7c8d-4e9f-8a0b-1c2d3e4f5a6b"
+	joinAccount(t, steve, "Steve")
+	joinAccount(t, alex, "Alex")
+	joinAccount(t, herobrine, "Herobrine")
+	joinAccount(t, notch, "Notch")
+
+	t.Run("Send and accept", func(tt *testing.T) {
+		require.Equal(tt, constants.FriendStatusOutgoing, sendFriendRequest(tt, http.StatusOK, steve, alex))
+		sendFriendRequest(tt, http.StatusConflict, steve, alex)
+		sendFriendRequest(tt, http.StatusBadRequest, steve, steve)
+		sendFriendRequest(tt, http.StatusNotFound, steve, "0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b")
+
+		friends := getFriends(tt, alex)
+		require.Len(tt, friends.Friends, 1)
+		require.Equal(tt, "Steve", friends.Friends[0].Name)
+		require.Equal(tt, constants.FriendStatusIncoming, friends.Friends[0].Status)
+		require.Equal(tt, constants.FriendStatusOutgoing, getFriends(tt, steve).Friends[0].Status)
+
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.FriendAcceptPath, nil, friendPair(steve, alex), nil)
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.FriendAcceptPath, nil, friendPair(alex, steve), nil)
+		require.Equal(tt, constants.FriendStatusFriend, getFriends(tt, steve).Friends[0].Status)
+		require.Equal(tt, constants.FriendStatusFriend, getFriends(tt, alex).Friends[0].Status)
+	})
+
+	t.Run("Mutual request accepts", func(tt *testing.T) {
+		require.Equal(tt, constants.FriendStatusOutgoing, sendFriendRequest(tt, http.StatusOK, herobrine, alex))
+		require.Equal(tt, constants.FriendStatusFriend, sendFriendRequest(tt, http.StatusOK, alex, herobrine))
+		require.Len(tt, getFriends(tt, alex).Friends, 2)
+	})
+
+	t.Run("Favourite", func(tt *testing.T) {
+		favourite := friendPair(alex, herobrine)
+		favourite.Set("favourite", "true")
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.FriendFavouritePath, nil, favourite, nil)
+
+		friends := getFriends(tt, alex).Friends
+		require.Equal(tt, "Herobrine", friends[0].Name)
+		require.True(tt, friends[0].Favourite)
+		require.False(tt, friends[1].Favourite)
+		require.False(tt, getFriends(tt, herobrine).Friends[0].Favourite)
+	})
+
+	t.Run("Decline and remove", func(tt *testing.T) {
+		sendFriendRequest(tt, http.StatusOK, steve, herobrine)
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.FriendDeclinePath, nil, friendPair(herobrine, steve), nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.FriendDeclinePath, nil, friendPair(herobrine, steve), nil)
+		require.Len(tt, getFriends(tt, herobrine).Friends, 1)
+
+		networkRequest(tt, http.StatusNoContent, http.MethodDelete, network.FriendsPath, friendPair(herobrine, alex), nil, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodDelete, network.FriendsPath, friendPair(herobrine, alex), nil, nil)
+		require.Empty(tt, getFriends(tt, herobrine).Friends)
+	})
+
+	t.Run("Privacy", func(tt *testing.T) {
+		require.Equal(tt, int16(constants.FriendPrivacyOpen), getFriends(tt, herobrine).Privacy)
+		networkRequest(tt, http.StatusNoContent, http.MethodPut, network.FriendPrivacyPath, nil, url.Values{
+			"uuid":    []string{herobrine},
+			"privacy": []string{"1"},
+		}, nil)
+		res := types.FriendPrivacyResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.FriendPrivacyPath, url.Values{"uuid": []string{herobrine}}, nil, &res)
+		require.Equal(tt, int16(constants.FriendPrivacyClosed), res.Privacy)
+
+		sendFriendRequest(tt, http.StatusConflict, steve, herobrine)
+		networkRequest(tt, http.StatusBadRequest, http.MethodPut, network.FriendPrivacyPath, nil, url.Values{
+			"uuid":    []string{herobrine},
+			"privacy": []string{"7"},
+		}, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPut, network.FriendPrivacyPath, nil, url.Values{
+			"uuid":    []string{"0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b"},
+			"privacy": []string{"1"},
+		}, nil)
+	})
+
+	t.Run("Friend limit", func(tt *testing.T) {
+		_, apiConf, _ := config.LoadConfig()
+		conf := apiConf.Network
+		defer network.LoadConfig(conf)
+
+		conf.FriendLimit = 1
+		network.LoadConfig(conf)
+
+		networkRequest(tt, http.StatusNoContent, http.MethodPut, network.FriendPrivacyPath, nil, url.Values{
+			"uuid":    []string{herobrine},
+			"privacy": []string{"0"},
+		}, nil)
+		sendFriendRequest(tt, http.StatusConflict, steve, herobrine)
+		sendFriendRequest(tt, http.StatusConflict, herobrine, steve)
+
+		conf.FriendLimit = 2
+		network.LoadConfig(conf)
+		require.Equal(tt, constants.FriendStatusOutgoing, sendFriendRequest(tt, http.StatusOK, herobrine, steve))
+
+		// Herobrine's request is incoming for Steve and does not use up one of his slots.
+		require.Equal(tt, constants.FriendStatusOutgoing, sendFriendRequest(tt, http.StatusOK, steve, notch))
+		networkRequest(tt, http.StatusConflict, http.MethodPost, network.FriendAcceptPath, nil, friendPair(steve, herobrine), nil)
+		sendFriendRequest(tt, http.StatusConflict, steve, herobrine)
+	})
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/routes/v1/gateway"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func registerIp(t *testing.T, ip string) string {
+	addIpInfo(t, http.StatusNoContent, ip)
+	ips := getIpInfo(t, http.StatusOK, ip)
+	require.NotEmpty(t, ips)
+	return strconv.FormatInt(ips[0].Id, 10)
+}
+
+func refusedLogin(t *testing.T, uuid string, ipId string) string {
+	res := postErrorResponse(t, http.StatusForbidden, gateway.RouteGroup+gateway.PlayerLoginPath, url.Values{
+		"uuid": []string{uuid},
+		"ipid": []string{ipId},
+	})
+	return res.Error.Code
+}
+
+func TestPlayerIps(t *testing.T) {
+	main := "4d5e6f70-8192-4a3b-8c4d-5e6f70819203"
+	alt := "5e6f7081-92a3-4b4c-9d5e-6f708192a3b4"
+	stranger := "6f708192-a3b4-4c5d-8e6f-708192a3b4c5"
+	admin := "708192a3-b4c5-4d6e-9f70-8192a3b4c5d6"
+	joinAccount(t, main, "Main_Account")
+	joinAccount(t, admin, "Ip_Moderator")
+	addPlayerInfo(t, http.StatusNoContent, main, "Main_Account", "47")
+	addPlayerInfo(t, http.StatusNoContent, alt, "Sneaky_Alt", "47")
+	addPlayerInfo(t, http.StatusNoContent, stranger, "Stranger", "47")
+
+	shared := registerIp(t, "203.0.113.50")
+	other := registerIp(t, "203.0.113.51")
+	handlePlayerLogin(t, http.StatusNoContent, main, shared)
+	handlePlayerLogin(t, http.StatusNoContent, main, shared)
+	handlePlayerLogin(t, http.StatusNoContent, alt, shared)
+
+	t.Run("Lookups", func(tt *testing.T) {
+		var ips []types.AccountIpResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.AccountIpsPath, url.Values{"uuid": []string{main}}, nil, &ips)
+		require.Len(tt, ips, 1)
+		require.Equal(tt, "203.0.113.50", ips[0].IpAddress)
+		require.Equal(tt, int64(2), ips[0].Logins)
+
+		var accounts []types.IpAccountResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.IpAccountsPath, url.Values{"ip": []string{"203.0.113.50"}}, nil, &accounts)
+		require.Len(tt, accounts, 2)
+
+		networkRequest(tt, http.StatusBadRequest, http.MethodGet, network.IpAccountsPath, url.Values{"ip": []string{"not-an-ip"}}, nil, nil)
+	})
+
+	t.Run("IP ban", func(tt *testing.T) {
+		res := types.IpBanIdResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.IpBansPath, nil, url.Values{
+			"ip":       []string{"203.0.113.51"},
+			"reason":   []string{"Bot network"},
+			"duration": []string{"24"},
+			"admin":    []string{admin},
+		}, &res)
+		require.Equal(tt, constants.ErrorIpBanned, refusedLogin(tt, stranger, other))
+
+		var bans []types.IpBanResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.IpBansPath, url.Values{
+			"ip":     []string{"203.0.113.51"},
+			"active": []string{"true"},
+		}, nil, &bans)
+		require.Len(tt, bans, 1)
+		require.True(tt, bans[0].Expires.Valid)
+
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.IpBanRemovePath, nil, url.Values{
+			"id":     []string{strconv.FormatInt(res.Id, 10)},
+			"reason": []string{"Shared school network"},
+			"admin":  []string{admin},
+		}, nil)
+		handlePlayerLogin(tt, http.StatusNoContent, stranger, other)
+	})
+
+	t.Run("Ban extended to alts", func(tt *testing.T) {
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.PunishmentsPath, nil, url.Values{
+			"uuid":     []string{main},
+			"category": []string{"Hacking"},
+			"sentence": []string{constants.PunishmentSentenceBan},
+			"reason":   []string{"Kill aura"},
+			"duration": []string{"-1"},
+			"admin":    []string{admin},
+			"alts":     []string{"true"},
+		}, &types.PunishmentIdResponse{})
+
+		require.Equal(tt, constants.ErrorPlayerBanned, refusedLogin(tt, main, shared))
+		require.Equal(tt, constants.ErrorAltBanned, refusedLogin(tt, alt, shared))
+		require.Equal(tt, constants.ErrorAltBanned, refusedLogin(tt, stranger, shared))
+		handlePlayerLogin(tt, http.StatusNoContent, stranger, other)
+	})
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func kitForm(uuid string, kit string) url.Values {
+	return url.Values{"uuid": []string{uuid}, "kit": []string{kit}}
+}
+
+func unlockKit(t *testing.T, uuid string, kit string) bool {
+	res := types.KitUnlockResponse{}
+	networkRequest(t, http.StatusOK, http.MethodPost, network.KitUnlockPath, nil, kitForm(uuid, kit), &res)
+	return res.Unlocked
+}
+
+func getKits(t *testing.T, uuid string) []types.KitResponse {
+	var res []types.KitResponse
+	networkRequest(t, http.StatusOK, http.MethodGet, network.KitsPath, url.Values{"uuid": []string{uuid}}, nil, &res)
+	return res
+}
+
+func TestKits(t *testing.T) {
+	knight := "091a2b3c-4d5e-4f6a-8b7c-8d9e0f1a2b3c"
+	archer := "1a2b3c4d-5e6f-4a7b-9c8d-9e0f1a2b3c4d"
+	joinAccount(t, knight, "Kit_Knight")
+	joinAccount(t, archer, "Kit_Archer")
+
+	t.Run("Unlock", func(tt *testing.T) {
+		require.True(tt, unlockKit(tt, knight, "7"))
+		require.False(tt, unlockKit(tt, knight, "7"))
+		require.True(tt, unlockKit(tt, archer, "7"))
+		require.True(tt, unlockKit(tt, archer, "8"))
+
+		kits := getKits(tt, archer)
+		require.Len(tt, kits, 2)
+		require.Equal(tt, int64(7), kits[0].KitId)
+		require.Equal(tt, int64(0), kits[0].Level)
+		require.NotNil(tt, kits[0].NextLevelXp)
+		require.Empty(tt, kits[0].Stats)
+	})
+
+	t.Run("Active", func(tt *testing.T) {
+		form := kitForm(knight, "7")
+		form.Set("active", "false")
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.KitActivePath, nil, form, nil)
+		require.False(tt, getKits(tt, knight)[0].Active)
+
+		form = kitForm(knight, "8")
+		form.Set("active", "true")
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.KitActivePath, nil, form, nil)
+	})
+
+	t.Run("Xp", func(tt *testing.T) {
+		form := kitForm(knight, "7")
+		form.Set("amount", "260")
+		res := types.KitXpResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.KitXpPath, nil, form, &res)
+		require.Equal(tt, int64(260), res.Xp)
+		require.Equal(tt, int64(0), res.PreviousLevel)
+		require.Greater(tt, res.Level, int64(0))
+		require.NotNil(tt, res.NextLevelXp)
+		require.Greater(tt, *res.NextLevelXp, res.Xp)
+
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.KitXpPath, nil, form, &res)
+		require.Equal(tt, int64(520), res.Xp)
+		require.GreaterOrEqual(tt, res.Level, res.PreviousLevel)
+
+		form = kitForm(knight, "8")
+		form.Set("amount", "10")
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.KitXpPath, nil, form, nil)
+	})
+
+	t.Run("Stats", func(tt *testing.T) {
+		form := kitForm(knight, "7")
+		form.Set("Kills", "3")
+		form.Set("Deaths", "1")
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.KitStatsPath, nil, form, nil)
+		form.Set("Kills", "2")
+		form.Del("Deaths")
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.KitStatsPath, nil, form, nil)
+
+		kits := getKits(tt, knight)
+		require.Equal(tt, map[string]int64{"Kills": 5, "Deaths": 1}, kits[0].Stats)
+		require.Empty(tt, getKits(tt, archer)[0].Stats)
+
+		form = kitForm(knight, "8")
+		form.Set("Kills", "1")
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.KitStatsPath, nil, form, nil)
+		networkRequest(tt, http.StatusBadRequest, http.MethodPost, network.KitStatsPath, nil, kitForm(knight, "7"), nil)
+	})
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func createPoll(t *testing.T, expectStatus int, form url.Values) int64 {
+	res := types.PollIdResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.PollsPath, nil, form, &res)
+	return res.Id
+}
+
+func setPollEnabled(t *testing.T, id int64, enabled bool) {
+	networkRequest(t, http.StatusNoContent, http.MethodPost, network.PollEnabledPath, nil, url.Values{
+		"id":      []string{strconv.FormatInt(id, 10)},
+		"enabled": []string{strconv.FormatBool(enabled)},
+	}, nil)
+}
+
+func answerPoll(t *testing.T, expectStatus int, uuid string, id int64, answer string) types.PollAnswerResponse {
+	res := types.PollAnswerResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.AccountPollAnswerPath, nil, url.Values{
+		"uuid":   []string{uuid},
+		"id":     []string{strconv.FormatInt(id, 10)},
+		"answer": []string{answer},
+	}, &res)
+	return res
+}
+
+func TestPolls(t *testing.T) {
+	voter := "b4c5d6e7-f809-4a1b-9c2d-3e4f5a6b7c8d"
+	other := "c5d6e7f8-091a-4b2c-8d3e-4f5a6b7c8d9e"
+	joinAccount(t, voter, "Poll_Voter")
+	joinAccount(t, other, "Poll_Skeptic")
+
+	favourite := createPoll(t, http.StatusOK, url.Values{
+		"question":   []string{"Favourite stew?"},
+		"answerA":    []string{"Mushroom"},
+		"answerB":    []string{"Rabbit"},
+		"answerC":    []string{"Suspicious"},
+		"coinReward": []string{"50"},
+		"admin":      []string{other},
+	})
+	followUp := createPoll(t, http.StatusOK, url.Values{
+		"question": []string{"Would you eat it again?"},
+		"answerA":  []string{"Yes"},
+		"answerB":  []string{"No"},
+	})
+	createPoll(t, http.StatusBadRequest, url.Values{
+		"question": []string{"Gaps?"},
+		"answerA":  []string{"A"},
+		"answerC":  []string{"C"},
+	})
+	for _, field := range []string{"coinReward", "displayType"} {
+		createPoll(t, http.StatusBadRequest, url.Values{
+			"question": []string{"Negative?"},
+			"answerA":  []string{"A"},
+			field:      []string{"-1"},
+		})
+	}
+
+	t.Run("Disabled polls are hidden", func(tt *testing.T) {
+		networkRequest(tt, http.StatusNoContent, http.MethodGet, network.AccountNextPollPath, url.Values{"uuid": []string{voter}}, nil, nil)
+		answerPoll(tt, http.StatusConflict, voter, favourite, "1")
+	})
+
+	setPollEnabled(t, favourite, true)
+	setPollEnabled(t, followUp, true)
+
+	t.Run("Answer", func(tt *testing.T) {
+		next := types.PollResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.AccountNextPollPath, url.Values{"uuid": []string{voter}}, nil, &next)
+		require.Equal(tt, favourite, next.Id)
+		require.Equal(tt, []string{"Mushroom", "Rabbit", "Suspicious"}, next.Answers)
+
+		answerPoll(tt, http.StatusBadRequest, voter, favourite, "4")
+		res := answerPoll(tt, http.StatusOK, voter, favourite, "3")
+		require.Equal(tt, int64(50), res.Reward)
+		require.Equal(tt, int64(50), res.Coins)
+		answerPoll(tt, http.StatusConflict, voter, favourite, "1")
+		answerPoll(tt, http.StatusOK, other, favourite, "3")
+
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.AccountNextPollPath, url.Values{"uuid": []string{voter}}, nil, &next)
+		require.Equal(tt, followUp, next.Id)
+		require.Equal(tt, int64(0), answerPoll(tt, http.StatusOK, voter, followUp, "2").Reward)
+		networkRequest(tt, http.StatusNoContent, http.MethodGet, network.AccountNextPollPath, url.Values{"uuid": []string{voter}}, nil, nil)
+
+		answerPoll(tt, http.StatusNotFound, voter, followUp+100, "1")
+	})
+
+	t.Run("Results", func(tt *testing.T) {
+		results := types.PollResultsResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.PollResultsPath,
+			url.Values{"id": []string{strconv.FormatInt(favourite, 10)}}, nil, &results)
+		require.Equal(tt, int64(2), results.Total)
+		require.Len(tt, results.Answers, 3)
+		require.Equal(tt, int64(0), results.Answers[0].Votes)
+		require.Equal(tt, int64(2), results.Answers[2].Votes)
+
+		var polls []types.PollResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.PollsPath, nil, nil, &polls)
+		require.GreaterOrEqual(tt, len(polls), 2)
+	})
+}
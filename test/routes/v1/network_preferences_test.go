@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func definePreference(t *testing.T, expectStatus int, id string, name string, def string) {
+	networkRequest(t, expectStatus, http.MethodPut, network.PreferenceDefinitionsPath, nil, url.Values{
+		"id":          []string{id},
+		"name":        []string{name},
+		"default":     []string{def},
+		"description": []string{"Test preference " + name},
+	}, nil)
+}
+
+func preferenceValues(preferences []types.PreferenceResponse) map[string]bool {
+	res := map[string]bool{}
+	for _, preference := range preferences {
+		res[preference.Name] = preference.Value
+	}
+	return res
+}
+
+func TestPreferences(t *testing.T) {
+	player := "a3b4c5d6-e7f8-4091-8a2b-3c4d5e6f7081"
+	joinAccount(t, player, "Picky_Player")
+
+	t.Run("Definitions", func(tt *testing.T) {
+		definePreference(tt, http.StatusNoContent, "1", "showChat", "true")
+		definePreference(tt, http.StatusNoContent, "2", "showPlayers", "true")
+		definePreference(tt, http.StatusNoContent, "3", "friendMessages", "false")
+		definePreference(tt, http.StatusNoContent, "3", "privateMessages", "false")
+		definePreference(tt, http.StatusConflict, "4", "showChat", "false")
+		definePreference(tt, http.StatusBadRequest, "5", "no spaces", "false")
+
+		var definitions []types.PreferenceDefinitionResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.PreferenceDefinitionsPath, nil, nil, &definitions)
+		require.Len(tt, definitions, 3)
+		require.Equal(tt, "privateMessages", definitions[2].Name)
+	})
+
+	t.Run("Defaults", func(tt *testing.T) {
+		var preferences []types.PreferenceResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.PreferencesPath, url.Values{"uuid": []string{player}}, nil, &preferences)
+		require.Equal(tt, map[string]bool{"showChat": true, "showPlayers": true, "privateMessages": false}, preferenceValues(preferences))
+		for _, preference := range preferences {
+			require.True(tt, preference.IsDefault)
+		}
+	})
+
+	t.Run("Bulk set", func(tt *testing.T) {
+		var preferences []types.PreferenceResponse
+		networkRequest(tt, http.StatusOK, http.MethodPut, network.PreferencesPath, nil, url.Values{
+			"uuid":            []string{player},
+			"showChat":        []string{"false"},
+			"privateMessages": []string{"true"},
+		}, &preferences)
+		require.Equal(tt, map[string]bool{"showChat": false, "showPlayers": true, "privateMessages": true}, preferenceValues(preferences))
+
+		networkRequest(tt, http.StatusBadRequest, http.MethodPut, network.PreferencesPath, nil, url.Values{
+			"uuid":      []string{player},
+			"notAThing": []string{"true"},
+		}, nil)
+		networkRequest(tt, http.StatusBadRequest, http.MethodPut, network.PreferencesPath, nil, url.Values{
+			"uuid":     []string{player},
+			"showChat": []string{"maybe"},
+		}, nil)
+		networkRequest(tt, http.StatusBadRequest, http.MethodPut, network.PreferencesPath, nil, url.Values{
+			"uuid": []string{player},
+		}, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPut, network.PreferencesPath, nil, url.Values{
+			"uuid":     []string{"0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b"},
+			"showChat": []string{"true"},
+		}, nil)
+	})
+}
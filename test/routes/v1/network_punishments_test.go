@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/routes/v1/gateway"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func addPunishment(t *testing.T, expectStatus int, uuid string, sentence string, duration string, admin string) int64 {
+	res := types.PunishmentIdResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.PunishmentsPath, nil, url.Values{
+		"uuid":     []string{uuid},
+		"category": []string{"Hacking"},
+		"sentence": []string{sentence},
+		"reason":   []string{"Flying in the lobby"},
+		"duration": []string{duration},
+		"admin":    []string{admin},
+	}, &res)
+	return res.Id
+}
+
+func getPunishments(t *testing.T, uuid string, active bool) []types.PunishmentResponse {
+	var res []types.PunishmentResponse
+	networkRequest(t, http.StatusOK, http.MethodGet, network.PunishmentsPath, url.Values{
+		"uuid":   []string{uuid},
+		"active": []string{strconv.FormatBool(active)},
+	}, nil, &res)
+	return res
+}
+
+func TestPunishments(t *testing.T) {
+	player := "2b3c4d5e-6f70-4a81-9b2c-3d4e5f607182"
+	admin := "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	joinAccount(t, player, "Sky_Flyer")
+	joinAccount(t, admin, "Moderator")
+
+	addPlayerInfo(t, http.StatusNoContent, player, "Sky_Flyer", "47")
+	addIpInfo(t, http.StatusNoContent, "203.0.113.40")
+	ipId := strconv.FormatInt(getIpInfo(t, http.StatusOK, "203.0.113.40")[0].Id, 10)
+	handlePlayerLogin(t, http.StatusNoContent, player, ipId)
+
+	var banId int64
+	t.Run("Issue", func(tt *testing.T) {
+		addPunishment(tt, http.StatusOK, player, constants.PunishmentSentenceWarning, "0", admin)
+		addPunishment(tt, http.StatusOK, player, constants.PunishmentSentenceMute, "0.5", admin)
+		banId = addPunishment(tt, http.StatusOK, player, constants.PunishmentSentenceBan, "-1", admin)
+		require.Greater(tt, banId, int64(0))
+
+		addPunishment(tt, http.StatusBadRequest, player, "Kick", "1", admin)
+		addPunishment(tt, http.StatusBadRequest, player, constants.PunishmentSentenceBan, "-2", admin)
+		addPunishment(tt, http.StatusNotFound, player, constants.PunishmentSentenceBan, "1", "0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b")
+	})
+
+	t.Run("List", func(tt *testing.T) {
+		all := getPunishments(tt, player, false)
+		require.Len(tt, all, 3)
+
+		active := getPunishments(tt, player, true)
+		require.Len(tt, active, 2)
+		for _, punishment := range active {
+			require.True(tt, punishment.Active)
+			if punishment.Sentence == constants.PunishmentSentenceBan {
+				require.False(tt, punishment.Expires.Valid)
+			} else {
+				require.True(tt, punishment.Expires.Valid)
+			}
+		}
+	})
+
+	t.Run("Banned login", func(tt *testing.T) {
+		res := postErrorResponse(tt, http.StatusForbidden, gateway.RouteGroup+gateway.PlayerLoginPath, url.Values{
+			"uuid": []string{player},
+			"ipid": []string{ipId},
+		})
+		require.Equal(tt, constants.ErrorPlayerBanned, res.Error.Code)
+		require.Contains(tt, res.Error.Message, "Flying in the lobby")
+	})
+
+	t.Run("Remove", func(tt *testing.T) {
+		remove := url.Values{
+			"id":     []string{strconv.FormatInt(banId, 10)},
+			"reason": []string{"Appeal accepted"},
+			"admin":  []string{admin},
+		}
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.PunishmentRemovePath, nil, remove, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.PunishmentRemovePath, nil, remove, nil)
+
+		require.Len(tt, getPunishments(tt, player, true), 1)
+		handlePlayerLogin(tt, http.StatusNoContent, player, ipId)
+	})
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func grantRank(t *testing.T, expectStatus int, uuid string, rank string, primary string, duration string, admin string) {
+	networkRequest(t, expectStatus, http.MethodPost, network.RanksPath, nil, url.Values{
+		"uuid":     []string{uuid},
+		"rank":     []string{rank},
+		"primary":  []string{primary},
+		"duration": []string{duration},
+		"admin":    []string{admin},
+	}, nil)
+}
+
+func getRanks(t *testing.T, uuid string) []types.RankResponse {
+	var res []types.RankResponse
+	networkRequest(t, http.StatusOK, http.MethodGet, network.RanksPath, url.Values{"uuid": []string{uuid}}, nil, &res)
+	return res
+}
+
+func TestRanks(t *testing.T) {
+	player := "8192a3b4-c5d6-4e7f-8a09-1b2c3d4e5f60"
+	admin := "92a3b4c5-d6e7-4f80-9a1b-2c3d4e5f6071"
+	joinAccount(t, player, "Rank_Climber")
+	joinAccount(t, admin, "Rank_Admin")
+
+	t.Run("Default rank", func(tt *testing.T) {
+		ranks := getRanks(tt, player)
+		require.Len(tt, ranks, 1)
+		require.Equal(tt, constants.RankDefault, ranks[0].Rank)
+		require.True(tt, ranks[0].PrimaryGroup)
+	})
+
+	t.Run("Grant", func(tt *testing.T) {
+		grantRank(tt, http.StatusNoContent, player, "vip", "true", "", admin)
+		grantRank(tt, http.StatusNoContent, player, "BUILDER", "false", "48", admin)
+		grantRank(tt, http.StatusNoContent, player, "MOD", "true", "", admin)
+
+		ranks := getRanks(tt, player)
+		require.Len(tt, ranks, 3)
+		require.Equal(tt, "MOD", ranks[0].Rank)
+		require.True(tt, ranks[0].PrimaryGroup)
+		require.True(tt, ranks[0].GrantedBy.Valid)
+		for _, rank := range ranks[1:] {
+			require.False(tt, rank.PrimaryGroup)
+			if rank.Rank == "BUILDER" {
+				require.True(tt, rank.Expires.Valid)
+			}
+		}
+
+		grantRank(tt, http.StatusBadRequest, player, "WAY_TOO_LONG", "true", "", admin)
+		grantRank(tt, http.StatusNotFound, "0b9f6a1e-2c3d-4e5f-9a8b-7c6d5e4f3a2b", "VIP", "true", "", admin)
+	})
+
+	t.Run("Expired rank", func(tt *testing.T) {
+		grantRank(tt, http.StatusNoContent, player, "TRIAL", "false", "0", admin)
+		for _, rank := range getRanks(tt, player) {
+			require.NotEqual(tt, "TRIAL", rank.Rank)
+		}
+	})
+
+	t.Run("Members", func(tt *testing.T) {
+		var members []types.RankMemberResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.RankMembersPath, url.Values{"rank": []string{"mod"}}, nil, &members)
+		require.Len(tt, members, 1)
+		require.Equal(tt, "Rank_Climber", members[0].Name)
+	})
+
+	t.Run("Revoke", func(tt *testing.T) {
+		revoke := url.Values{"uuid": []string{player}, "rank": []string{"MOD"}, "admin": []string{admin}}
+		networkRequest(tt, http.StatusNoContent, http.MethodPost, network.RankRevokePath, nil, revoke, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.RankRevokePath, nil, revoke, nil)
+
+		ranks := getRanks(tt, player)
+		require.Equal(tt, constants.RankDefault, ranks[0].Rank)
+		require.True(tt, ranks[0].PrimaryGroup)
+
+		var log []types.RankLogResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.RankLogPath, url.Values{"uuid": []string{player}}, nil, &log)
+		require.Len(tt, log, 5)
+		require.Equal(tt, "revoke", log[0].Action)
+		require.Equal(tt, "MOD", log[0].Rank)
+	})
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func fileReport(t *testing.T, expectStatus int, reporter string, suspect string, category string, weight string) types.FileReportResponse {
+	res := types.FileReportResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.ReportsPath, nil, url.Values{
+		"reporter": []string{reporter},
+		"suspect":  []string{suspect},
+		"category": []string{category},
+		"reason":   []string{"Flying around spawn"},
+		"server":   []string{"Lobby-1"},
+		"weight":   []string{weight},
+	}, &res)
+	return res
+}
+
+func reportAction(t *testing.T, expectStatus int, path string, handler string, id int64) {
+	networkRequest(t, expectStatus, http.MethodPost, path, nil, url.Values{
+		"handler": []string{handler},
+		"id":      []string{strconv.FormatInt(id, 10)},
+	}, nil)
+}
+
+func closeReport(t *testing.T, expectStatus int, handler string, id int64) {
+	networkRequest(t, expectStatus, http.MethodPost, network.ReportClosePath, nil, url.Values{
+		"handler": []string{handler},
+		"id":      []string{strconv.FormatInt(id, 10)},
+		"result":  []string{"31"},
+		"reason":  []string{"Confirmed"},
+	}, nil)
+}
+
+func claimNextReport(t *testing.T, expectStatus int, handler string) types.ReportResponse {
+	res := types.ReportResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.ReportClaimPath, nil, url.Values{
+		"handler": []string{handler},
+	}, &res)
+	return res
+}
+
+func TestReports(t *testing.T) {
+	reporter := "2f3a4b5c-6d7e-4f8a-9b9c-0d1e2f3a4b5c"
+	witness := "3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c6d"
+	suspect := "4b5c6d7e-8f9a-4bac-9d1e-2f3a4b5c6d7e"
+	moderator := "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e8f"
+	outsider := "6d7e8f9a-0b1c-4d2e-9f3a-4b5c6d7e8f9a"
+	joinAccount(t, reporter, "Report_Reporter")
+	joinAccount(t, witness, "Report_Witness")
+	joinAccount(t, suspect, "Report_Suspect")
+	joinAccount(t, moderator, "Report_Moderator")
+	joinAccount(t, outsider, "Report_Outsider")
+
+	for _, team := range []url.Values{
+		{"id": []string{"11"}, "name": []string{"Hacking"}},
+		{"id": []string{"12"}, "name": []string{"Chat"}},
+	} {
+		networkRequest(t, http.StatusNoContent, http.MethodPut, network.ReportTeamsPath, nil, team, nil)
+	}
+	networkRequest(t, http.StatusNoContent, http.MethodPut, network.ReportCategoriesPath, nil, url.Values{
+		"id": []string{"21"}, "name": []string{"Hacking"}, "team": []string{"11"},
+	}, nil)
+	networkRequest(t, http.StatusNoContent, http.MethodPut, network.ReportResultTypesPath, nil, url.Values{
+		"id": []string{"31"}, "name": []string{"Accepted"}, "globalStat": []string{"true"},
+	}, nil)
+	networkRequest(t, http.StatusNoContent, http.MethodPut, network.ReportTeamMembersPath, nil, url.Values{
+		"uuid": []string{moderator}, "team": []string{"11"},
+	}, nil)
+	networkRequest(t, http.StatusNoContent, http.MethodPut, network.ReportTeamMembersPath, nil, url.Values{
+		"uuid": []string{outsider}, "team": []string{"12"},
+	}, nil)
+
+	var id int64
+	t.Run("File", func(tt *testing.T) {
+		first := fileReport(tt, http.StatusOK, reporter, suspect, "21", "")
+		require.False(tt, first.Merged)
+		require.Equal(tt, int64(1), first.Weight)
+		id = first.Id
+
+		second := fileReport(tt, http.StatusOK, witness, suspect, "21", "3")
+		require.True(tt, second.Merged)
+		require.Equal(tt, id, second.Id)
+		require.Equal(tt, int64(4), second.Weight)
+
+		again := fileReport(tt, http.StatusOK, reporter, suspect, "21", "")
+		require.Equal(tt, int64(4), again.Weight)
+
+		other := fileReport(tt, http.StatusOK, reporter, witness, "21", "")
+		require.NotEqual(tt, id, other.Id)
+
+		fileReport(tt, http.StatusBadRequest, suspect, suspect, "21", "")
+		fileReport(tt, http.StatusNotFound, reporter, suspect, "29", "")
+
+		var open []types.ReportResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.ReportsPath, url.Values{"team": []string{"11"}}, nil, &open)
+		require.GreaterOrEqual(tt, len(open), 2)
+		require.Equal(tt, id, open[0].Id)
+		require.Equal(tt, int64(2), open[0].Reporters)
+		require.False(tt, open[0].HandlerUUID.Valid)
+	})
+
+	t.Run("Handle", func(tt *testing.T) {
+		claimNextReport(tt, http.StatusForbidden, reporter)
+		reportAction(tt, http.StatusForbidden, network.ReportClaimPath, outsider, id)
+		closeReport(tt, http.StatusConflict, moderator, id)
+
+		claimed := claimNextReport(tt, http.StatusOK, moderator)
+		require.Equal(tt, id, claimed.Id)
+		require.True(tt, claimed.HandlerUUID.Valid)
+
+		reportAction(tt, http.StatusNoContent, network.ReportAbortPath, moderator, id)
+		reportAction(tt, http.StatusConflict, network.ReportAbortPath, moderator, id)
+		reportAction(tt, http.StatusOK, network.ReportClaimPath, moderator, id)
+
+		closeReport(tt, http.StatusForbidden, outsider, id)
+		closeReport(tt, http.StatusOK, moderator, id)
+		closeReport(tt, http.StatusConflict, moderator, id)
+		reportAction(tt, http.StatusConflict, network.ReportClaimPath, moderator, id)
+
+		next := fileReport(tt, http.StatusOK, witness, suspect, "21", "")
+		require.False(tt, next.Merged)
+
+		for {
+			report := claimNextReport(tt, http.StatusOK, moderator)
+			closeReport(tt, http.StatusOK, moderator, report.Id)
+			if report.Id == next.Id {
+				break
+			}
+		}
+		claimNextReport(tt, http.StatusNoContent, moderator)
+
+		networkRequest(tt, http.StatusNoContent, http.MethodDelete, network.ReportTeamMembersPath, url.Values{"uuid": []string{moderator}}, nil, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodDelete, network.ReportTeamMembersPath, url.Values{"uuid": []string{moderator}}, nil, nil)
+		claimNextReport(tt, http.StatusForbidden, moderator)
+	})
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func minutesAgo(minutes int) string {
+	return strconv.FormatInt(time.Now().Add(-time.Duration(minutes)*time.Minute).UnixMilli(), 10)
+}
+
+func TestSnapshots(t *testing.T) {
+	suspect := "7e8f9a0b-1c2d-4e3f-8a4b-5c6d7e8f9a0b"
+	friend := "8f9a0b1c-2d3e-4f4a-9b5c-6d7e8f9a0b1c"
+	bystander := "9a0b1c2d-3e4f-4a5b-8c6d-7e8f9a0b1c2d"
+	joinAccount(t, suspect, "Snap_Suspect")
+	joinAccount(t, friend, "Snap_Friend")
+	joinAccount(t, bystander, "Snap_Bystander")
+
+	t.Run("Ingest", func(tt *testing.T) {
+		res := types.SnapshotMessagesAddedResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.SnapshotMessagesPath, nil, url.Values{
+			"server":     []string{"Lobby-2"},
+			"sender":     []string{suspect, suspect, friend, bystander},
+			"message":    []string{"ancient history", "anyone want a free rank?", "stop it", "hello"},
+			"type":       []string{"1", "1", "2", "1"},
+			"recipients": []string{friend, strings.Join([]string{friend, bystander}, ","), suspect, ""},
+			"time":       []string{minutesAgo(120), minutesAgo(3), minutesAgo(2), minutesAgo(1)},
+		}, &res)
+		require.Equal(tt, int32(4), res.Added)
+
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.SnapshotMessagesPath, nil, url.Values{
+			"server":     []string{"Lobby-2"},
+			"sender":     []string{bystander},
+			"message":    []string{"stamped by the database"},
+			"type":       []string{"1"},
+			"recipients": []string{""},
+		}, &res)
+		require.Equal(tt, int32(1), res.Added)
+
+		for _, form := range []url.Values{
+			{"server": {"Lobby-2"}, "sender": {suspect}, "message": {"hi"}, "type": {"1"}},
+			{"server": {"Lobby-2"}, "sender": {suspect}, "message": {"hi"}, "type": {"9"}, "recipients": {""}},
+			{"server": {"Lobby-2"}, "sender": {suspect}, "message": {"hi"}, "type": {"1"}, "recipients": {"nobody"}},
+			{"server": {"Lobby-2"}, "sender": {suspect}, "message": {""}, "type": {"1"}, "recipients": {""}},
+		} {
+			networkRequest(tt, http.StatusBadRequest, http.MethodPost, network.SnapshotMessagesPath, nil, form, nil)
+		}
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.SnapshotMessagesPath, nil, url.Values{
+			"server":     []string{"Lobby-2"},
+			"sender":     []string{"a0b1c2d3-e4f5-4a6b-9c7d-8e9f0a1b2c3d"},
+			"message":    []string{"who am I"},
+			"type":       []string{"1"},
+			"recipients": []string{""},
+		}, nil)
+	})
+
+	t.Run("Snapshot", func(tt *testing.T) {
+		networkRequest(tt, http.StatusNoContent, http.MethodPut, network.ReportTeamsPath, nil, url.Values{
+			"id": []string{"13"}, "name": []string{"Snapshots"},
+		}, nil)
+		networkRequest(tt, http.StatusNoContent, http.MethodPut, network.ReportCategoriesPath, nil, url.Values{
+			"id": []string{"22"}, "name": []string{"Spam"}, "team": []string{"13"},
+		}, nil)
+		report := fileReport(tt, http.StatusOK, bystander, suspect, "22", "")
+
+		created := types.SnapshotCreatedResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.ReportSnapshotPath, nil, url.Values{
+			"id":      []string{strconv.FormatInt(report.Id, 10)},
+			"creator": []string{bystander},
+		}, &created)
+		require.Equal(tt, int32(2), created.Messages)
+
+		var open []types.ReportResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.ReportsPath, url.Values{"team": []string{"13"}}, nil, &open)
+		require.Len(tt, open, 1)
+		require.NotNil(tt, open[0].SnapshotId)
+		require.Equal(tt, created.Id, *open[0].SnapshotId)
+
+		var messages []types.SnapshotMessageResponse
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.SnapshotsPath, url.Values{
+			"id": []string{strconv.FormatInt(created.Id, 10)},
+		}, nil, &messages)
+		require.Len(tt, messages, 2)
+		require.Equal(tt, "Snap_Suspect", messages[0].SenderName)
+		require.Equal(tt, "anyone want a free rank?", messages[0].Message)
+		require.Len(tt, messages[0].Recipients, 2)
+		require.Equal(tt, "Snap_Friend", messages[1].SenderName)
+		require.Equal(tt, "PRIVATE_MESSAGE", messages[1].Type)
+		require.Len(tt, messages[1].Recipients, 1)
+
+		// A new snapshot replaces the old one, which is deleted so its messages can be pruned.
+		replaced := types.SnapshotCreatedResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.ReportSnapshotPath, nil, url.Values{
+			"id":      []string{strconv.FormatInt(report.Id, 10)},
+			"creator": []string{bystander},
+		}, &replaced)
+		require.NotEqual(tt, created.Id, replaced.Id)
+		networkRequest(tt, http.StatusNotFound, http.MethodGet, network.SnapshotsPath, url.Values{
+			"id": []string{strconv.FormatInt(created.Id, 10)},
+		}, nil, nil)
+
+		networkRequest(tt, http.StatusNotFound, http.MethodGet, network.SnapshotsPath, url.Values{"id": []string{"999999"}}, nil, nil)
+		networkRequest(tt, http.StatusNotFound, http.MethodPost, network.ReportSnapshotPath, nil, url.Values{
+			"id":      []string{"999999"},
+			"creator": []string{bystander},
+		}, nil)
+	})
+}
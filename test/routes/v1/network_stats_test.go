@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/constants"
+	"stew/routes/v1/network"
+	"stew/types"
+	"testing"
+)
+
+func incrementStats(t *testing.T, expectStatus int, uuid string, stats map[string]string) {
+	form := url.Values{"uuid": []string{uuid}}
+	for name, amount := range stats {
+		form.Set(name, amount)
+	}
+	networkRequest(t, expectStatus, http.MethodPost, network.StatsPath, nil, form, nil)
+}
+
+func getLeaderboard(t *testing.T, expectStatus int, path string, query url.Values) []types.LeaderboardEntryResponse {
+	var res []types.LeaderboardEntryResponse
+	networkRequest(t, expectStatus, http.MethodGet, path, query, nil, &res)
+	return res
+}
+
+func TestStats(t *testing.T) {
+	first := "2b3c4d5e-6f7a-4b8c-8d9e-0f1a2b3c4d5e"
+	second := "3c4d5e6f-7a8b-4c9d-9e0f-1a2b3c4d5e6f"
+	third := "4d5e6f7a-8b9c-4dae-8f1a-2b3c4d5e6f7a"
+	joinAccount(t, first, "Stat_First")
+	joinAccount(t, second, "Stat_Second")
+	joinAccount(t, third, "Stat_Third")
+
+	t.Run("Increment", func(tt *testing.T) {
+		incrementStats(tt, http.StatusNoContent, first, map[string]string{"Lobby.Wins": "20", "Lobby.Losses": "1"})
+		incrementStats(tt, http.StatusNoContent, first, map[string]string{"Lobby.Wins": "10"})
+		incrementStats(tt, http.StatusNoContent, second, map[string]string{"Lobby.Wins": "20"})
+		incrementStats(tt, http.StatusNoContent, third, map[string]string{"Lobby.Wins": "10"})
+		incrementStats(tt, http.StatusBadRequest, first, map[string]string{"Lobby Wins": "1"})
+		incrementStats(tt, http.StatusBadRequest, first, map[string]string{"Lobby.Wins": "many"})
+		incrementStats(tt, http.StatusNotFound, "5e6f7a8b-9cad-4ebf-9a2b-3c4d5e6f7a8b", map[string]string{"Lobby.Wins": "1"})
+
+		for _, window := range []string{"", constants.StatWindowWeekly, constants.StatWindowMonthly} {
+			var stats []types.StatResponse
+			networkRequest(tt, http.StatusOK, http.MethodGet, network.StatsPath, url.Values{
+				"uuid":   []string{first},
+				"window": []string{window},
+			}, nil, &stats)
+			require.Equal(tt, []types.StatResponse{{Name: "Lobby.Losses", Value: 1}, {Name: "Lobby.Wins", Value: 30}}, stats)
+		}
+	})
+
+	t.Run("Leaderboard", func(tt *testing.T) {
+		top := getLeaderboard(tt, http.StatusOK, network.LeaderboardPath, url.Values{"stat": []string{"Lobby.Wins"}})
+		require.Len(tt, top, 3)
+		require.Equal(tt, "Stat_First", top[0].Name)
+		require.Equal(tt, int64(1), top[0].Rank)
+		require.Equal(tt, int64(30), top[0].Value)
+		require.Equal(tt, int64(2), top[1].Rank)
+		require.Equal(tt, int64(3), top[2].Rank)
+
+		top = getLeaderboard(tt, http.StatusOK, network.LeaderboardPath, url.Values{
+			"stat":   []string{"Lobby.Wins"},
+			"window": []string{constants.StatWindowWeekly},
+			"limit":  []string{"1"},
+			"offset": []string{"1"},
+		})
+		require.Len(tt, top, 1)
+		require.Equal(tt, "Stat_Second", top[0].Name)
+
+		require.Empty(tt, getLeaderboard(tt, http.StatusOK, network.LeaderboardPath, url.Values{"stat": []string{"Lobby.Nothing"}}))
+		getLeaderboard(tt, http.StatusBadRequest, network.LeaderboardPath, url.Values{
+			"stat":   []string{"Lobby.Wins"},
+			"window": []string{"daily"},
+		})
+	})
+
+	t.Run("Rank", func(tt *testing.T) {
+		res := types.LeaderboardEntryResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodGet, network.LeaderboardRankPath, url.Values{
+			"stat": []string{"Lobby.Wins"},
+			"uuid": []string{third},
+		}, nil, &res)
+		require.Equal(tt, int64(3), res.Rank)
+		require.Equal(tt, int64(10), res.Value)
+
+		networkRequest(tt, http.StatusNotFound, http.MethodGet, network.LeaderboardRankPath, url.Values{
+			"stat": []string{"Lobby.Losses"},
+			"uuid": []string{third},
+		}, nil, nil)
+	})
+
+	t.Run("Around", func(tt *testing.T) {
+		around := getLeaderboard(tt, http.StatusOK, network.LeaderboardAroundPath, url.Values{
+			"stat":  []string{"Lobby.Wins"},
+			"uuid":  []string{second},
+			"range": []string{"1"},
+		})
+		require.Len(tt, around, 3)
+		require.Equal(tt, "Stat_Second", around[1].Name)
+
+		around = getLeaderboard(tt, http.StatusOK, network.LeaderboardAroundPath, url.Values{
+			"stat":  []string{"Lobby.Wins"},
+			"uuid":  []string{first},
+			"range": []string{"1"},
+		})
+		require.Len(tt, around, 2)
+
+		getLeaderboard(tt, http.StatusNotFound, network.LeaderboardAroundPath, url.Values{
+			"stat": []string{"Lobby.Losses"},
+			"uuid": []string{second},
+		})
+	})
+}
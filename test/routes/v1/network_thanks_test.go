@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func addThank(t *testing.T, expectStatus int, receiver string, sender string, amount int64, ignoreCooldown bool) types.ThankResponse {
+	res := types.ThankResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.ThanksPath, nil, url.Values{
+		"receiver":       []string{receiver},
+		"sender":         []string{sender},
+		"amount":         []string{strconv.FormatInt(amount, 10)},
+		"reason":         []string{"GG"},
+		"ignoreCooldown": []string{strconv.FormatBool(ignoreCooldown)},
+	}, &res)
+	return res
+}
+
+func checkAmplifierThank(t *testing.T, uuid string, amplifierId string) bool {
+	res := types.AmplifierThankResponse{}
+	networkRequest(t, http.StatusOK, http.MethodPost, network.ThankAmplifierPath, nil, url.Values{
+		"uuid":        []string{uuid},
+		"amplifierId": []string{amplifierId},
+	}, &res)
+	return res.CanThank
+}
+
+func TestThanks(t *testing.T) {
+	receiver := "d6e7f809-1a2b-4c3d-9e4f-5a6b7c8d9e0f"
+	first := "e7f8091a-2b3c-4d4e-8f5a-6b7c8d9e0f1a"
+	second := "f8091a2b-3c4d-4e5f-9a6b-7c8d9e0f1a2b"
+	joinAccount(t, receiver, "Thank_Receiver")
+	joinAccount(t, first, "Thank_First")
+	joinAccount(t, second, "Thank_Second")
+
+	t.Run("Cooldown", func(tt *testing.T) {
+		require.True(tt, addThank(tt, http.StatusOK, receiver, first, 10, false).Success)
+
+		res := addThank(tt, http.StatusOK, receiver, first, 10, false)
+		require.False(tt, res.Success)
+		require.Greater(tt, res.CooldownRemaining, int32(0))
+
+		require.True(tt, addThank(tt, http.StatusOK, receiver, first, 5, true).Success)
+		require.True(tt, addThank(tt, http.StatusOK, receiver, second, 20, false).Success)
+
+		addThank(tt, http.StatusBadRequest, receiver, receiver, 10, false)
+		addThank(tt, http.StatusBadRequest, receiver, first, 0, false)
+	})
+
+	t.Run("Amplifier", func(tt *testing.T) {
+		amplifierId := "424242"
+		require.True(tt, checkAmplifierThank(tt, first, amplifierId))
+		require.False(tt, checkAmplifierThank(tt, first, amplifierId))
+		require.True(tt, checkAmplifierThank(tt, second, amplifierId))
+	})
+
+	t.Run("Claim", func(tt *testing.T) {
+		before := getAccount(tt, http.StatusOK, url.Values{"uuid": []string{receiver}})
+
+		res := types.ClaimThankResponse{}
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.ThankClaimPath, nil, url.Values{
+			"uuid": []string{receiver},
+		}, &res)
+		require.Equal(tt, int64(35), res.AmountClaimed)
+		require.Equal(tt, int32(2), res.UniqueThank)
+		require.Equal(tt, before.Coins+35, res.Coins)
+
+		networkRequest(tt, http.StatusOK, http.MethodPost, network.ThankClaimPath, nil, url.Values{
+			"uuid": []string{receiver},
+		}, &res)
+		require.Equal(tt, int64(0), res.AmountClaimed)
+		require.Equal(tt, int32(0), res.UniqueThank)
+	})
+}
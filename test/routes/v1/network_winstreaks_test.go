@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"stew/routes/v1/network"
+	"stew/types"
+	"strconv"
+	"testing"
+)
+
+func reportWinstreakResult(t *testing.T, expectStatus int, uuid string, game string, won bool) types.WinstreakResultResponse {
+	res := types.WinstreakResultResponse{}
+	networkRequest(t, expectStatus, http.MethodPost, network.WinstreaksPath, nil, url.Values{
+		"uuid": []string{uuid},
+		"game": []string{game},
+		"won":  []string{strconv.FormatBool(won)},
+	}, &res)
+	return res
+}
+
+func TestWinstreaks(t *testing.T) {
+	player := "0d1e2f3a-4b5c-4d6e-9f7a-8b9c0d1e2f3a"
+	joinAccount(t, player, "Streak_Runner")
+
+	for i := int64(1); i <= 3; i++ {
+		res := reportWinstreakResult(t, http.StatusOK, player, "5", true)
+		require.Equal(t, i, res.Streak)
+		require.Equal(t, i-1, res.PreviousStreak)
+		require.Equal(t, i, res.Best)
+		require.True(t, res.NewBest)
+	}
+
+	res := reportWinstreakResult(t, http.StatusOK, player, "5", false)
+	require.Equal(t, int64(0), res.Streak)
+	require.Equal(t, int64(3), res.PreviousStreak)
+	require.Equal(t, int64(3), res.Best)
+	require.False(t, res.NewBest)
+
+	res = reportWinstreakResult(t, http.StatusOK, player, "5", true)
+	require.Equal(t, int64(1), res.Streak)
+	require.False(t, res.NewBest)
+
+	reportWinstreakResult(t, http.StatusOK, player, "6", false)
+	reportWinstreakResult(t, http.StatusNotFound, "1e2f3a4b-5c6d-4e7f-8a8b-9c0d1e2f3a4b", "5", true)
+	reportWinstreakResult(t, http.StatusBadRequest, player, "0", false)
+
+	var streaks []types.WinstreakResponse
+	networkRequest(t, http.StatusOK, http.MethodGet, network.WinstreaksPath, url.Values{"uuid": []string{player}}, nil, &streaks)
+	require.Equal(t, []types.WinstreakResponse{
+		{GameId: 5, Streak: 1, Best: 3},
+		{GameId: 6, Streak: 0, Best: 0},
+	}, streaks)
+}
@@ -11,7 +11,9 @@ import (
 	"os"
 	"path"
 	"stew/config"
+	"stew/constants"
 	"stew/database"
+	"stew/embeds"
 	"stew/logging"
 	"stew/router"
 	"stew/routes"
@@ -29,26 +31,38 @@ func TestMain(m *testing.M) {
 
 	logging.AppLogger.Info("Creating config for testing")
 	godotenv.Load(path.Join("..", "..", "..", ".env"))
-	dbConf, apiConf := config.LoadConfig()
+	dbConf, apiConf, logConf := config.LoadConfig()
+	logConf.Level = logrus.DebugLevel
+	logging.ApplyConfig(logConf)
 
 	logging.AppLogger.Info("Loading database")
 	db := database.LoadDatabase(dbConf)
 	database.ConnectDatabase(db)
 
-	for _, sc := range []string{"player_stats.sql", "accounts.sql"} {
-		logging.AppLogger.Info(fmt.Sprintf("Running SQL script %s", sc))
-		fbytes, err := os.ReadFile(path.Join("..", "..", "..", "sql", sc))
-		if err != nil {
-			panic(err)
-		}
-		sqlScript := string(fbytes)
-		ctx, cancel := database.SetTimeout(3)
-		defer cancel()
-		_, err = db.Exec(ctx, sqlScript)
-		if err != nil {
-			panic(err)
-		}
+	logging.AppLogger.Info("Resetting database")
+	ctx, cancel := database.SetTimeout(3)
+	defer cancel()
+	_, err := db.Exec(ctx, `DROP SCHEMA IF EXISTS stew_meta CASCADE;
+DROP SCHEMA IF EXISTS stew_auth CASCADE;
+DROP SCHEMA IF EXISTS stew_accounts CASCADE;
+DROP SCHEMA IF EXISTS stew_player_stats CASCADE;`)
+	if err != nil {
+		panic(err)
+	}
+
+	logging.AppLogger.Info("Running database migrations")
+	embeds.InitDBEmbed(os.DirFS(path.Join("..", "..", "..", "sql")), "migrations")
+	err = database.Migrate(db)
+	if err != nil {
+		panic(err)
+	}
+
+	logging.AppLogger.Info("Creating api key for testing")
+	testAPIKey, err := database.CreateAPIKey("test", []string{constants.ScopeAll})
+	if err != nil {
+		panic(err)
 	}
+	http.DefaultTransport = &apiKeyTransport{key: testAPIKey, base: unauthenticatedTransport}
 
 	logging.AppLogger.Info("Loading router")
 	router.LoadRouter(apiConf)
@@ -70,6 +84,21 @@ func TestMain(m *testing.M) {
 	defer db.Close()
 }
 
+var unauthenticatedTransport = http.DefaultTransport
+
+type apiKeyTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(router.AuthorizationHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(router.AuthorizationHeader, "Bearer "+t.key)
+	}
+	return t.base.RoundTrip(req)
+}
+
 func testRequestIdHeader(t *testing.T, resp *http.Response) {
 	head := resp.Header.Get(router.RequestIdHeader)
 	require.NotEmpty(t, head)
@@ -0,0 +1,12 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type AccountResponse struct {
+	UUID          pgtype.UUID      `json:"uuid"`
+	Name          string           `json:"name"`
+	Gems          int64            `json:"gems"`
+	Coins         int64            `json:"coins"`
+	LastLogin     pgtype.Timestamp `json:"lastLogin"`
+	TotalPlayTime int              `json:"totalPlayTime"`
+}
@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+type APIKey struct {
+	Id          int64
+	Name        string
+	Scopes      []string
+	CreatedTime time.Time
+	Revoked     bool
+}
@@ -1,5 +1,7 @@
 package types
 
+import "github.com/sirupsen/logrus"
+
 // PostgreSQL
 type DatabaseConfig struct {
 	SQLHost     string
@@ -9,9 +11,37 @@ type DatabaseConfig struct {
 	SQLDatabase string
 	MinConns    int32
 	MaxConns    int32
+
+	MigrateOnStart bool
 }
 
 type APIConfig struct {
 	ListenAddress string
 	ListenPort    uint16
+
+	AuthEnabled        bool
+	APIKeyCacheSeconds int32
+
+	ShutdownTimeoutSeconds int32
+
+	MaxBodyBytes int32
+
+	Network NetworkConfig
+}
+
+type NetworkConfig struct {
+	FriendLimit          int32
+	ThankCooldownSeconds int32
+	KitLevelXp           []int64
+	EloKFactor           int32
+	EloInitialRating     int32
+
+	// 0 leaves pruning to `snapshots prune`.
+	SnapshotRetentionDays int32
+}
+
+type LogConfig struct {
+	Format       string
+	Level        logrus.Level
+	RedactFields []string
 }
@@ -0,0 +1,35 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type CurrencyChangeResponse struct {
+	TransactionId int64 `json:"transactionId"`
+	Balance       int64 `json:"balance"`
+	Replayed      bool  `json:"replayed"`
+}
+
+type CurrencyTransferResponse struct {
+	TransactionId int64 `json:"transactionId"`
+	FromBalance   int64 `json:"fromBalance"`
+	ToBalance     int64 `json:"toBalance"`
+	Replayed      bool  `json:"replayed"`
+}
+
+type CurrencyTransactionResponse struct {
+	Id               int64            `json:"id"`
+	PlayerUUID       pgtype.UUID      `json:"playerUUID"`
+	Currency         string           `json:"currency"`
+	Amount           int64            `json:"amount"`
+	Balance          int64            `json:"balance"`
+	Reason           string           `json:"reason"`
+	Server           string           `json:"server"`
+	ActorUUID        pgtype.UUID      `json:"actorUUID"`
+	CounterpartyUUID pgtype.UUID      `json:"counterpartyUUID"`
+	IdempotencyKey   pgtype.Text      `json:"idempotencyKey"`
+	Time             pgtype.Timestamp `json:"time"`
+}
+
+type CurrencyHistoryResponse struct {
+	Transactions []CurrencyTransactionResponse `json:"transactions"`
+	NextBefore   *int64                        `json:"nextBefore"`
+}
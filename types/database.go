@@ -0,0 +1,7 @@
+package types
+
+// Code is one of the constants.Error* codes.
+type DatabaseError struct {
+	Code   string
+	Column string
+}
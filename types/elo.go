@@ -0,0 +1,23 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type EloResponse struct {
+	GameId int64 `json:"gameId"`
+	Elo    int64 `json:"elo"`
+	Rated  bool  `json:"rated"`
+}
+
+type EloChangeResponse struct {
+	UUID        pgtype.UUID `json:"uuid"`
+	Team        int32       `json:"team"`
+	Placement   int32       `json:"placement"`
+	PreviousElo int64       `json:"previousElo"`
+	Elo         int64       `json:"elo"`
+}
+
+type EloOpponentResponse struct {
+	UUID pgtype.UUID `json:"uuid"`
+	Name string      `json:"name"`
+	Elo  int64       `json:"elo"`
+}
@@ -0,0 +1,12 @@
+package types
+
+type ErrorBody struct {
+	Code      string `json:"code"`
+	Field     string `json:"field,omitempty"`
+	Message   string `json:"message"`
+	RequestId string `json:"request_id"`
+}
+
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
@@ -0,0 +1,23 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type FriendResponse struct {
+	UUID      pgtype.UUID `json:"uuid"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	Favourite bool        `json:"favourite"`
+}
+
+type FriendsResponse struct {
+	Privacy int16            `json:"privacy"`
+	Friends []FriendResponse `json:"friends"`
+}
+
+type FriendRequestResponse struct {
+	Status string `json:"status"`
+}
+
+type FriendPrivacyResponse struct {
+	Privacy int16 `json:"privacy"`
+}
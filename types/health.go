@@ -0,0 +1,32 @@
+package types
+
+type HealthResponse struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+type ReadinessCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type MigrationsReadinessCheck struct {
+	ReadinessCheck
+	CurrentVersion int64 `json:"currentVersion"`
+	LatestVersion  int64 `json:"latestVersion"`
+	Pending        int   `json:"pending"`
+}
+
+type PoolStatsResponse struct {
+	AcquiredConns int32 `json:"acquiredConns"`
+	IdleConns     int32 `json:"idleConns"`
+	TotalConns    int32 `json:"totalConns"`
+	MaxConns      int32 `json:"maxConns"`
+}
+
+type ReadinessResponse struct {
+	Status     string                   `json:"status"`
+	Database   ReadinessCheck           `json:"database"`
+	Migrations MigrationsReadinessCheck `json:"migrations"`
+	Pool       PoolStatsResponse        `json:"pool"`
+}
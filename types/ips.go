@@ -0,0 +1,37 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type AccountIpResponse struct {
+	IpAddress string           `json:"ipAddress"`
+	FirstSeen pgtype.Timestamp `json:"firstSeen"`
+	LastSeen  pgtype.Timestamp `json:"lastSeen"`
+	Logins    int64            `json:"logins"`
+}
+
+type IpAccountResponse struct {
+	UUID      pgtype.UUID      `json:"uuid"`
+	Name      string           `json:"name"`
+	FirstSeen pgtype.Timestamp `json:"firstSeen"`
+	LastSeen  pgtype.Timestamp `json:"lastSeen"`
+	Logins    int64            `json:"logins"`
+}
+
+type IpBanResponse struct {
+	Id               int64            `json:"id"`
+	IpAddress        string           `json:"ipAddress"`
+	Reason           string           `json:"reason"`
+	Time             pgtype.Timestamp `json:"time"`
+	Duration         float64          `json:"duration"`
+	AdminUUID        pgtype.UUID      `json:"adminUUID"`
+	Removed          bool             `json:"removed"`
+	ReasonOfRemoval  string           `json:"reasonOfRemoval"`
+	RemoverAdminUUID pgtype.UUID      `json:"removerAdminUUID"`
+	RemovedTime      pgtype.Timestamp `json:"removedTime"`
+	Expires          pgtype.Timestamp `json:"expires"`
+	Active           bool             `json:"active"`
+}
+
+type IpBanIdResponse struct {
+	Id int64 `json:"id"`
+}
@@ -0,0 +1,22 @@
+package types
+
+type KitResponse struct {
+	KitId        int64            `json:"kitId"`
+	Active       bool             `json:"active"`
+	Xp           int64            `json:"xp"`
+	Level        int64            `json:"level"`
+	NextLevelXp  *int64           `json:"nextLevelXp"`
+	UpgradeLevel int64            `json:"upgradeLevel"`
+	Stats        map[string]int64 `json:"stats"`
+}
+
+type KitUnlockResponse struct {
+	Unlocked bool `json:"unlocked"`
+}
+
+type KitXpResponse struct {
+	Xp            int64  `json:"xp"`
+	Level         int64  `json:"level"`
+	PreviousLevel int64  `json:"previousLevel"`
+	NextLevelXp   *int64 `json:"nextLevelXp"`
+}
@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+type Migration struct {
+	Version    int64
+	Name       string
+	UpScript   string
+	DownScript string
+}
+
+type MigrationStatus struct {
+	Version     int64
+	Name        string
+	Known       bool
+	Applied     bool
+	AppliedTime time.Time
+}
+
+type MigrationState struct {
+	Initialised    bool
+	CurrentVersion int64
+	LatestVersion  int64
+	Pending        int
+	Unknown        int
+}
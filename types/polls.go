@@ -0,0 +1,34 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type PollResponse struct {
+	Id          int64            `json:"id"`
+	Enabled     bool             `json:"enabled"`
+	Question    string           `json:"question"`
+	Answers     []string         `json:"answers"`
+	CoinReward  int64            `json:"coinReward"`
+	DisplayType int              `json:"displayType"`
+	CreatedBy   pgtype.UUID      `json:"createdBy"`
+	CreatedTime pgtype.Timestamp `json:"createdTime"`
+}
+
+type PollIdResponse struct {
+	Id int64 `json:"id"`
+}
+
+type PollAnswerResponse struct {
+	Reward int64 `json:"reward"`
+	Coins  int64 `json:"coins"`
+}
+
+type PollResultResponse struct {
+	Answer int16  `json:"answer"`
+	Text   string `json:"text"`
+	Votes  int64  `json:"votes"`
+}
+
+type PollResultsResponse struct {
+	Total   int64                `json:"total"`
+	Answers []PollResultResponse `json:"answers"`
+}
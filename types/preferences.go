@@ -0,0 +1,15 @@
+package types
+
+type PreferenceDefinitionResponse struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Default     bool   `json:"default"`
+	Description string `json:"description"`
+}
+
+type PreferenceResponse struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Value     bool   `json:"value"`
+	IsDefault bool   `json:"isDefault"`
+}
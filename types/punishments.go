@@ -0,0 +1,26 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type PunishmentResponse struct {
+	Id               int64            `json:"id"`
+	PlayerUUID       pgtype.UUID      `json:"playerUUID"`
+	Category         string           `json:"category"`
+	Sentence         string           `json:"sentence"`
+	Reason           string           `json:"reason"`
+	Time             pgtype.Timestamp `json:"time"`
+	Duration         float64          `json:"duration"`
+	AdminUUID        pgtype.UUID      `json:"adminUUID"`
+	Severity         int16            `json:"severity"`
+	Removed          bool             `json:"removed"`
+	ReasonOfRemoval  string           `json:"reasonOfRemoval"`
+	RemoverAdminUUID pgtype.UUID      `json:"removerAdminUUID"`
+	RemovedTime      pgtype.Timestamp `json:"removedTime"`
+	ExtendToAlts     bool             `json:"extendToAlts"`
+	Expires          pgtype.Timestamp `json:"expires"`
+	Active           bool             `json:"active"`
+}
+
+type PunishmentIdResponse struct {
+	Id int64 `json:"id"`
+}
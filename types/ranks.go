@@ -0,0 +1,31 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type RankResponse struct {
+	Rank         string           `json:"rank"`
+	PrimaryGroup bool             `json:"primaryGroup"`
+	GrantedTime  pgtype.Timestamp `json:"grantedTime"`
+	GrantedBy    pgtype.UUID      `json:"grantedBy"`
+	Expires      pgtype.Timestamp `json:"expires"`
+}
+
+type RankMemberResponse struct {
+	UUID         pgtype.UUID      `json:"uuid"`
+	Name         string           `json:"name"`
+	PrimaryGroup bool             `json:"primaryGroup"`
+	GrantedTime  pgtype.Timestamp `json:"grantedTime"`
+	GrantedBy    pgtype.UUID      `json:"grantedBy"`
+	Expires      pgtype.Timestamp `json:"expires"`
+}
+
+type RankLogResponse struct {
+	Id           int64            `json:"id"`
+	PlayerUUID   pgtype.UUID      `json:"playerUUID"`
+	Rank         string           `json:"rank"`
+	Action       string           `json:"action"`
+	PrimaryGroup bool             `json:"primaryGroup"`
+	Expires      pgtype.Timestamp `json:"expires"`
+	AdminUUID    pgtype.UUID      `json:"adminUUID"`
+	Time         pgtype.Timestamp `json:"time"`
+}
@@ -0,0 +1,26 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type ReportResponse struct {
+	Id          int64            `json:"id"`
+	SuspectUUID pgtype.UUID      `json:"suspectUUID"`
+	SuspectName string           `json:"suspectName"`
+	CategoryId  int16            `json:"categoryId"`
+	TeamId      int16            `json:"teamId"`
+	Weight      int64            `json:"weight"`
+	Reporters   int64            `json:"reporters"`
+	SnapshotId  *int64           `json:"snapshotId"`
+	HandlerUUID pgtype.UUID      `json:"handlerUUID"`
+	CreatedTime pgtype.Timestamp `json:"createdTime"`
+}
+
+type FileReportResponse struct {
+	Id     int64 `json:"id"`
+	Merged bool  `json:"merged"`
+	Weight int64 `json:"weight"`
+}
+
+type ReportResultResponse struct {
+	ResultId int64 `json:"resultId"`
+}
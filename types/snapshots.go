@@ -0,0 +1,23 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type SnapshotMessagesAddedResponse struct {
+	Added int32 `json:"added"`
+}
+
+type SnapshotCreatedResponse struct {
+	Id       int64 `json:"id"`
+	Messages int32 `json:"messages"`
+}
+
+type SnapshotMessageResponse struct {
+	Id         int64            `json:"id"`
+	SenderUUID pgtype.UUID      `json:"senderUUID"`
+	SenderName string           `json:"senderName"`
+	Server     string           `json:"server"`
+	Time       pgtype.Timestamp `json:"time"`
+	Message    string           `json:"message"`
+	Type       string           `json:"type"`
+	Recipients []pgtype.UUID    `json:"recipients"`
+}
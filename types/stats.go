@@ -0,0 +1,15 @@
+package types
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type StatResponse struct {
+	Name  string `json:"name"`
+	Value int64  `json:"value"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank  int64       `json:"rank"`
+	UUID  pgtype.UUID `json:"uuid"`
+	Name  string      `json:"name"`
+	Value int64       `json:"value"`
+}
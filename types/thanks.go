@@ -0,0 +1,16 @@
+package types
+
+type ThankResponse struct {
+	Success           bool  `json:"success"`
+	CooldownRemaining int32 `json:"cooldownRemaining"`
+}
+
+type AmplifierThankResponse struct {
+	CanThank bool `json:"canThank"`
+}
+
+type ClaimThankResponse struct {
+	AmountClaimed int64 `json:"amountClaimed"`
+	UniqueThank   int32 `json:"uniqueThank"`
+	Coins         int64 `json:"coins"`
+}
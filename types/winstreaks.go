@@ -0,0 +1,14 @@
+package types
+
+type WinstreakResponse struct {
+	GameId int64 `json:"gameId"`
+	Streak int64 `json:"streak"`
+	Best   int64 `json:"best"`
+}
+
+type WinstreakResultResponse struct {
+	Streak         int64 `json:"streak"`
+	PreviousStreak int64 `json:"previousStreak"`
+	Best           int64 `json:"best"`
+	NewBest        bool  `json:"newBest"`
+}